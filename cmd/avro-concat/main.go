@@ -0,0 +1,111 @@
+// Command avro-concat concatenates object container files with the same
+// schema. Blocks are copied without being decoded unless their codec differs
+// from the output codec.
+//
+//	avro-concat -o out.avro [-codec deflate] in1.avro in2.avro ...
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avro"
+)
+
+func main() {
+	output := flag.String("o", "", "file to write")
+	codec := flag.String("codec", "", "codec of the output, defaults to the codec of the first input")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s -o out.avro [-codec name] in.avro ...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *output == "" || flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	err := concat(*output, avro.CompressionCodec(*codec), flag.Args())
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func concat(output string, codec avro.CompressionCodec, inputs []string) (err error) {
+	readers := make([]io.Reader, len(inputs))
+	for i, input := range inputs {
+		readers[i] = &lazyFile{
+			name: input,
+		}
+	}
+	// write to a temporary file renamed over output once complete, so
+	// output is left untouched if an input is missing, corrupt or output
+	// itself
+	file, err := ioutil.TempFile(filepath.Dir(output), "."+filepath.Base(output)+".*")
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			file.Close()
+			os.Remove(file.Name())
+		}
+	}()
+	err = avro.Concat(avro.ConcatInput{
+		Writer:           file,
+		Readers:          readers,
+		CompressionCodec: codec,
+	})
+	if err != nil {
+		return
+	}
+	err = file.Chmod(outputMode(output))
+	if err != nil {
+		return
+	}
+	err = file.Close()
+	if err != nil {
+		return
+	}
+	return os.Rename(file.Name(), output)
+}
+
+// outputMode keeps the permissions of an existing output, TempFile creates
+// files readable only by their owner.
+func outputMode(output string) os.FileMode {
+	info, err := os.Stat(output)
+	if err != nil {
+		return 0644
+	}
+	return info.Mode().Perm()
+}
+
+// lazyFile opens the named file on the first Read and closes it at EOF so
+// only one input is open at a time.
+type lazyFile struct {
+	name string
+	file *os.File
+	done bool
+}
+
+func (lazyFile *lazyFile) Read(p []byte) (n int, err error) {
+	if lazyFile.done {
+		return 0, io.EOF
+	}
+	if lazyFile.file == nil {
+		lazyFile.file, err = os.Open(lazyFile.name)
+		if err != nil {
+			return
+		}
+	}
+	n, err = lazyFile.file.Read(p)
+	if err == io.EOF {
+		lazyFile.done = true
+		lazyFile.file.Close()
+	}
+	return
+}
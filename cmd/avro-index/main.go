@@ -0,0 +1,63 @@
+// Command avro-index builds a block index for an object container file so it
+// can be read from a given record with FileReader.SeekToRecord.
+//
+//	avro-index [-o file.avro.index] file.avro
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avro"
+)
+
+func main() {
+	output := flag.String("o", "", "index file to write, defaults to the input file with .index appended")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [-o index] file.avro\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	input := flag.Arg(0)
+	if *output == "" {
+		*output = input + ".index"
+	}
+	err := buildIndex(input, *output)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func buildIndex(input, output string) (err error) {
+	file, err := os.Open(input)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	index, err := avro.BuildBlockIndex(file)
+	if err != nil {
+		return
+	}
+	indexFile, err := os.Create(output)
+	if err != nil {
+		return
+	}
+	defer indexFile.Close()
+	writer := bufio.NewWriter(indexFile)
+	_, err = index.WriteAvro(writer)
+	if err != nil {
+		return
+	}
+	err = writer.Flush()
+	if err != nil {
+		return
+	}
+	return indexFile.Close()
+}
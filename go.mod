@@ -4,5 +4,7 @@ go 1.13
 
 require (
 	github.com/golang/snappy v0.0.4
+	github.com/klauspost/compress v1.11.13
 	github.com/smartystreets/goconvey v1.6.4
+	github.com/ulikunitz/xz v0.5.15
 )
@@ -3,6 +3,8 @@ package internal
 import (
 	"encoding/binary"
 	"io"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avro"
 )
 
 func ReadBoolean(reader Reader, v interface{}) (err error) {
@@ -52,6 +54,10 @@ func ReadBytes(reader Reader, v interface{}) (err error) {
 	if err != nil {
 		return
 	}
+	err = avro.DecoderConfigOf(reader).CheckBytesLength(length)
+	if err != nil {
+		return
+	}
 	data := make([]byte, length)
 	_, err = io.ReadFull(reader, data)
 	if err != nil {
@@ -71,3 +77,26 @@ func ReadString(reader Reader, v interface{}) (err error) {
 	*value = string(data)
 	return
 }
+
+// readItemCount reads the item count of the next block of an array or map,
+// checking the running total against the reader's MaxItemCount.
+func readItemCount(reader Reader, total *int64) (count int64, err error) {
+	count, _, err = avro.ReadCollectionBlockHeader(reader)
+	if err != nil {
+		return
+	}
+	*total += count
+	err = avro.DecoderConfigOf(reader).CheckItemCount(*total)
+	return
+}
+
+// maxInitialCapacity bounds the capacity allocated up front for an item
+// count read from the data, larger collections grow as their items are read.
+const maxInitialCapacity = 1024
+
+func initialCapacity(count int64) int {
+	if count > maxInitialCapacity {
+		return maxInitialCapacity
+	}
+	return int(count)
+}
@@ -1,17 +1,16 @@
 package internal
 
-import "encoding/binary"
-
 func ReadBooleanArray(reader Reader, v interface{}) (err error) {
 	value := v.(*[]bool)
-	blockLength, err := binary.ReadVarint(reader)
+	var total int64
+	blockLength, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
 	if blockLength == 0 {
 		return
 	}
-	values := make([]bool, 0, blockLength)
+	values := make([]bool, 0, initialCapacity(blockLength))
 	for blockLength != 0 {
 		for i := int64(0); i < blockLength; i++ {
 			var value bool
@@ -21,7 +20,7 @@ func ReadBooleanArray(reader Reader, v interface{}) (err error) {
 			}
 			values = append(values, value)
 		}
-		blockLength, err = binary.ReadVarint(reader)
+		blockLength, err = readItemCount(reader, &total)
 		if err != nil {
 			return
 		}
@@ -32,14 +31,15 @@ func ReadBooleanArray(reader Reader, v interface{}) (err error) {
 
 func ReadIntArray(reader Reader, v interface{}) (err error) {
 	value := v.(*[]int32)
-	blockLength, err := binary.ReadVarint(reader)
+	var total int64
+	blockLength, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
 	if blockLength == 0 {
 		return
 	}
-	values := make([]int32, 0, blockLength)
+	values := make([]int32, 0, initialCapacity(blockLength))
 	for blockLength != 0 {
 		for i := int64(0); i < blockLength; i++ {
 			var value int32
@@ -49,7 +49,7 @@ func ReadIntArray(reader Reader, v interface{}) (err error) {
 			}
 			values = append(values, value)
 		}
-		blockLength, err = binary.ReadVarint(reader)
+		blockLength, err = readItemCount(reader, &total)
 		if err != nil {
 			return
 		}
@@ -60,14 +60,15 @@ func ReadIntArray(reader Reader, v interface{}) (err error) {
 
 func ReadLongArray(reader Reader, v interface{}) (err error) {
 	value := v.(*[]int64)
-	blockLength, err := binary.ReadVarint(reader)
+	var total int64
+	blockLength, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
 	if blockLength == 0 {
 		return
 	}
-	values := make([]int64, 0, blockLength)
+	values := make([]int64, 0, initialCapacity(blockLength))
 	for blockLength != 0 {
 		for i := int64(0); i < blockLength; i++ {
 			var value int64
@@ -77,7 +78,7 @@ func ReadLongArray(reader Reader, v interface{}) (err error) {
 			}
 			values = append(values, value)
 		}
-		blockLength, err = binary.ReadVarint(reader)
+		blockLength, err = readItemCount(reader, &total)
 		if err != nil {
 			return
 		}
@@ -88,14 +89,15 @@ func ReadLongArray(reader Reader, v interface{}) (err error) {
 
 func ReadFloatArray(reader Reader, v interface{}) (err error) {
 	value := v.(*[]float32)
-	blockLength, err := binary.ReadVarint(reader)
+	var total int64
+	blockLength, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
 	if blockLength == 0 {
 		return
 	}
-	values := make([]float32, 0, blockLength)
+	values := make([]float32, 0, initialCapacity(blockLength))
 	for blockLength != 0 {
 		for i := int64(0); i < blockLength; i++ {
 			var value float32
@@ -105,7 +107,7 @@ func ReadFloatArray(reader Reader, v interface{}) (err error) {
 			}
 			values = append(values, value)
 		}
-		blockLength, err = binary.ReadVarint(reader)
+		blockLength, err = readItemCount(reader, &total)
 		if err != nil {
 			return
 		}
@@ -116,14 +118,15 @@ func ReadFloatArray(reader Reader, v interface{}) (err error) {
 
 func ReadDoubleArray(reader Reader, v interface{}) (err error) {
 	value := v.(*[]float64)
-	blockLength, err := binary.ReadVarint(reader)
+	var total int64
+	blockLength, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
 	if blockLength == 0 {
 		return
 	}
-	values := make([]float64, 0, blockLength)
+	values := make([]float64, 0, initialCapacity(blockLength))
 	for blockLength != 0 {
 		for i := int64(0); i < blockLength; i++ {
 			var value float64
@@ -133,7 +136,7 @@ func ReadDoubleArray(reader Reader, v interface{}) (err error) {
 			}
 			values = append(values, value)
 		}
-		blockLength, err = binary.ReadVarint(reader)
+		blockLength, err = readItemCount(reader, &total)
 		if err != nil {
 			return
 		}
@@ -144,14 +147,15 @@ func ReadDoubleArray(reader Reader, v interface{}) (err error) {
 
 func ReadBytesArray(reader Reader, v interface{}) (err error) {
 	value := v.(*[][]byte)
-	blockLength, err := binary.ReadVarint(reader)
+	var total int64
+	blockLength, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
 	if blockLength == 0 {
 		return
 	}
-	values := make([][]byte, 0, blockLength)
+	values := make([][]byte, 0, initialCapacity(blockLength))
 	for blockLength != 0 {
 		for i := int64(0); i < blockLength; i++ {
 			var value []byte
@@ -161,7 +165,7 @@ func ReadBytesArray(reader Reader, v interface{}) (err error) {
 			}
 			values = append(values, value)
 		}
-		blockLength, err = binary.ReadVarint(reader)
+		blockLength, err = readItemCount(reader, &total)
 		if err != nil {
 			return
 		}
@@ -172,14 +176,15 @@ func ReadBytesArray(reader Reader, v interface{}) (err error) {
 
 func ReadStringArray(reader Reader, v interface{}) (err error) {
 	value := v.(*[]string)
-	blockLength, err := binary.ReadVarint(reader)
+	var total int64
+	blockLength, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
 	if blockLength == 0 {
 		return
 	}
-	values := make([]string, 0, blockLength)
+	values := make([]string, 0, initialCapacity(blockLength))
 	for blockLength != 0 {
 		for i := int64(0); i < blockLength; i++ {
 			var value string
@@ -189,7 +194,7 @@ func ReadStringArray(reader Reader, v interface{}) (err error) {
 			}
 			values = append(values, value)
 		}
-		blockLength, err = binary.ReadVarint(reader)
+		blockLength, err = readItemCount(reader, &total)
 		if err != nil {
 			return
 		}
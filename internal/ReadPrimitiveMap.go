@@ -1,14 +1,13 @@
 package internal
 
-import "encoding/binary"
-
 func ReadBooleanMap(reader Reader, v interface{}) (err error) {
 	value := v.(*map[string]bool)
-	blockLength, err := binary.ReadVarint(reader)
+	var total int64
+	blockLength, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
-	values := make(map[string]bool, blockLength)
+	values := make(map[string]bool, initialCapacity(blockLength))
 	for blockLength != 0 {
 		for i := int64(0); i < blockLength; i++ {
 			var key string
@@ -23,7 +22,7 @@ func ReadBooleanMap(reader Reader, v interface{}) (err error) {
 			}
 			values[key] = value
 		}
-		blockLength, err = binary.ReadVarint(reader)
+		blockLength, err = readItemCount(reader, &total)
 		if err != nil {
 			return
 		}
@@ -34,11 +33,12 @@ func ReadBooleanMap(reader Reader, v interface{}) (err error) {
 
 func ReadIntMap(reader Reader, v interface{}) (err error) {
 	value := v.(*map[string]int32)
-	blockLength, err := binary.ReadVarint(reader)
+	var total int64
+	blockLength, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
-	values := make(map[string]int32, blockLength)
+	values := make(map[string]int32, initialCapacity(blockLength))
 	for blockLength != 0 {
 		for i := int64(0); i < blockLength; i++ {
 			var key string
@@ -53,7 +53,7 @@ func ReadIntMap(reader Reader, v interface{}) (err error) {
 			}
 			values[key] = value
 		}
-		blockLength, err = binary.ReadVarint(reader)
+		blockLength, err = readItemCount(reader, &total)
 		if err != nil {
 			return
 		}
@@ -64,11 +64,12 @@ func ReadIntMap(reader Reader, v interface{}) (err error) {
 
 func ReadLongMap(reader Reader, v interface{}) (err error) {
 	value := v.(*map[string]int64)
-	blockLength, err := binary.ReadVarint(reader)
+	var total int64
+	blockLength, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
-	values := make(map[string]int64, blockLength)
+	values := make(map[string]int64, initialCapacity(blockLength))
 	for blockLength != 0 {
 		for i := int64(0); i < blockLength; i++ {
 			var key string
@@ -83,7 +84,7 @@ func ReadLongMap(reader Reader, v interface{}) (err error) {
 			}
 			values[key] = value
 		}
-		blockLength, err = binary.ReadVarint(reader)
+		blockLength, err = readItemCount(reader, &total)
 		if err != nil {
 			return
 		}
@@ -94,11 +95,12 @@ func ReadLongMap(reader Reader, v interface{}) (err error) {
 
 func ReadFloatMap(reader Reader, v interface{}) (err error) {
 	value := v.(*map[string]float32)
-	blockLength, err := binary.ReadVarint(reader)
+	var total int64
+	blockLength, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
-	values := make(map[string]float32, blockLength)
+	values := make(map[string]float32, initialCapacity(blockLength))
 	for blockLength != 0 {
 		for i := int64(0); i < blockLength; i++ {
 			var key string
@@ -113,7 +115,7 @@ func ReadFloatMap(reader Reader, v interface{}) (err error) {
 			}
 			values[key] = value
 		}
-		blockLength, err = binary.ReadVarint(reader)
+		blockLength, err = readItemCount(reader, &total)
 		if err != nil {
 			return
 		}
@@ -124,11 +126,12 @@ func ReadFloatMap(reader Reader, v interface{}) (err error) {
 
 func ReadDoubleMap(reader Reader, v interface{}) (err error) {
 	value := v.(*map[string]float64)
-	blockLength, err := binary.ReadVarint(reader)
+	var total int64
+	blockLength, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
-	values := make(map[string]float64, blockLength)
+	values := make(map[string]float64, initialCapacity(blockLength))
 	for blockLength != 0 {
 		for i := int64(0); i < blockLength; i++ {
 			var key string
@@ -143,7 +146,7 @@ func ReadDoubleMap(reader Reader, v interface{}) (err error) {
 			}
 			values[key] = value
 		}
-		blockLength, err = binary.ReadVarint(reader)
+		blockLength, err = readItemCount(reader, &total)
 		if err != nil {
 			return
 		}
@@ -154,11 +157,12 @@ func ReadDoubleMap(reader Reader, v interface{}) (err error) {
 
 func ReadBytesMap(reader Reader, v interface{}) (err error) {
 	value := v.(*map[string][]byte)
-	blockLength, err := binary.ReadVarint(reader)
+	var total int64
+	blockLength, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
-	values := make(map[string][]byte, blockLength)
+	values := make(map[string][]byte, initialCapacity(blockLength))
 	for blockLength != 0 {
 		for i := int64(0); i < blockLength; i++ {
 			var key string
@@ -173,7 +177,7 @@ func ReadBytesMap(reader Reader, v interface{}) (err error) {
 			}
 			values[key] = value
 		}
-		blockLength, err = binary.ReadVarint(reader)
+		blockLength, err = readItemCount(reader, &total)
 		if err != nil {
 			return
 		}
@@ -184,11 +188,12 @@ func ReadBytesMap(reader Reader, v interface{}) (err error) {
 
 func ReadStringMap(reader Reader, v interface{}) (err error) {
 	value := v.(*map[string]string)
-	blockLength, err := binary.ReadVarint(reader)
+	var total int64
+	blockLength, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
-	values := make(map[string]string, blockLength)
+	values := make(map[string]string, initialCapacity(blockLength))
 	for blockLength != 0 {
 		for i := int64(0); i < blockLength; i++ {
 			var key string
@@ -203,7 +208,7 @@ func ReadStringMap(reader Reader, v interface{}) (err error) {
 			}
 			values[key] = value
 		}
-		blockLength, err = binary.ReadVarint(reader)
+		blockLength, err = readItemCount(reader, &total)
 		if err != nil {
 			return
 		}
@@ -0,0 +1,136 @@
+package avro
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"sort"
+)
+
+var ErrIndexMismatch = errors.New("block index does not match file")
+
+var ErrRecordOutOfRange = errors.New("record out of range")
+
+// BlockIndex maps the blocks of an object container file to their offsets
+// and the number of records they end at, allowing a FileReader to seek to a
+// record without decoding the blocks before it. It is encoded as an avro
+// record of the file's sync marker and an array of entries.
+type BlockIndex struct {
+	Sync    [16]byte
+	Entries []BlockIndexEntry
+}
+
+type BlockIndexEntry struct {
+	// Offset is the position of the block in the file.
+	Offset int64
+	// Count is the number of records in this and all preceding blocks.
+	Count int64
+}
+
+// BuildBlockIndex indexes the object container file read from reader.
+func BuildBlockIndex(reader io.Reader) (index *BlockIndex, err error) {
+	headerReader := &countingReader{
+		reader: bufio.NewReader(reader),
+	}
+	var header ObjectContainerHeader
+	err = header.ReadAvro(headerReader)
+	if err != nil {
+		return
+	}
+	blockIterator := NewObjectBlockIterator(NewObjectBlockIteratorInput{
+		Reader:       headerReader.reader,
+		ExpectedSync: header.Sync,
+		Offset:       headerReader.offset,
+	})
+	index = &BlockIndex{
+		Sync: header.Sync,
+	}
+	var block ObjectBlock
+	for blockIterator.Next(&block) {
+		index.add(blockIterator.BlockOffset(), block.Length)
+	}
+	err = blockIterator.Err()
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Count returns the number of records in the indexed file.
+func (index *BlockIndex) Count() int64 {
+	if len(index.Entries) == 0 {
+		return 0
+	}
+	return index.Entries[len(index.Entries)-1].Count
+}
+
+// Search returns the number of the block containing record n, counting from
+// zero, or ErrRecordOutOfRange.
+func (index *BlockIndex) Search(n int64) (block int, err error) {
+	if n < 0 || n >= index.Count() {
+		return 0, ErrRecordOutOfRange
+	}
+	block = sort.Search(len(index.Entries), func(i int) bool {
+		return index.Entries[i].Count > n
+	})
+	return
+}
+
+func (index *BlockIndex) add(offset int64, length int64) {
+	index.Entries = append(index.Entries, BlockIndexEntry{
+		Offset: offset,
+		Count:  index.Count() + length,
+	})
+}
+
+func (index *BlockIndex) ReadAvro(reader Reader) (err error) {
+	_, err = io.ReadFull(reader, index.Sync[:])
+	if err != nil {
+		return
+	}
+	index.Entries = index.Entries[:0]
+	return ReadArray(reader, func(int) error {
+		var entry BlockIndexEntry
+		err := entry.ReadAvro(reader)
+		if err != nil {
+			return err
+		}
+		index.Entries = append(index.Entries, entry)
+		return nil
+	})
+}
+
+func (index *BlockIndex) WriteAvro(writer Writer) (nWritten int, err error) {
+	nWritten, err = writer.Write(index.Sync[:])
+	if err != nil {
+		return
+	}
+	n, err := WriteArray(writer, len(index.Entries), func(i int) (int, error) {
+		return index.Entries[i].WriteAvro(writer)
+	})
+	nWritten += n
+	return
+}
+
+func (entry *BlockIndexEntry) ReadAvro(reader Reader) (err error) {
+	err = ReadLong(reader, &entry.Offset)
+	if err != nil {
+		return
+	}
+	err = ReadLong(reader, &entry.Count)
+	if err != nil {
+		return
+	}
+	return
+}
+
+func (entry *BlockIndexEntry) WriteAvro(writer Writer) (nWritten int, err error) {
+	n, err := WriteLong(writer, entry.Offset)
+	nWritten += n
+	if err != nil {
+		return
+	}
+	n, err = WriteLong(writer, entry.Count)
+	nWritten += n
+	return
+}
@@ -0,0 +1,80 @@
+package avro_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avro"
+)
+
+func TestBlockIndex(t *testing.T) {
+	Convey("TestBlockIndex", t, func() {
+		people := generatePeople(100)
+		write := func(workers int) (file []byte, index *avro.BlockIndex) {
+			var buffer, indexBuffer bytes.Buffer
+			fileWriter, err := avro.NewFileWriter(avro.NewFileWriterInput{
+				Writer:             &buffer,
+				Schema:             parsePersonSchema(),
+				CompressionCodec:   avro.CompressionCodecDeflate,
+				MaxBlockLength:     7,
+				CompressionWorkers: workers,
+				Index:              &indexBuffer,
+			})
+			So(err, ShouldBeNil)
+			for i := range people {
+				err = fileWriter.Append(&people[i])
+				So(err, ShouldBeNil)
+			}
+			err = fileWriter.Close()
+			So(err, ShouldBeNil)
+			index = new(avro.BlockIndex)
+			err = index.ReadAvro(&indexBuffer)
+			So(err, ShouldBeNil)
+			return buffer.Bytes(), index
+		}
+		for _, workers := range []int{0, 4} {
+			file, index := write(workers)
+			Convey(fmt.Sprintf("matches BuildBlockIndex with %d workers", workers), func() {
+				built, err := avro.BuildBlockIndex(bytes.NewReader(file))
+				So(err, ShouldBeNil)
+				So(index, ShouldResemble, built)
+				So(len(index.Entries), ShouldEqual, 15)
+				So(index.Count(), ShouldEqual, len(people))
+			})
+		}
+		file, index := write(0)
+		Convey("seeks to record", func() {
+			fileReader, err := avro.NewFileReader(avro.NewFileReaderInput{
+				Reader: bytes.NewReader(file),
+				Index:  index,
+			})
+			So(err, ShouldBeNil)
+			var person Person
+			for _, n := range []int64{99, 0, 7, 50, 13} {
+				err = fileReader.SeekToRecord(n)
+				So(err, ShouldBeNil)
+				So(fileReader.Next(&person), ShouldBeTrue)
+				So(person, ShouldResemble, people[n])
+			}
+			count := 1
+			for fileReader.Next(&person) {
+				count++
+			}
+			So(fileReader.Err(), ShouldBeNil)
+			So(count, ShouldEqual, len(people)-13)
+			err = fileReader.SeekToRecord(100)
+			So(err, ShouldEqual, avro.ErrRecordOutOfRange)
+		})
+		Convey("index from another file", func() {
+			other, _ := write(0)
+			_, err := avro.NewFileReader(avro.NewFileReaderInput{
+				Reader: bytes.NewReader(other),
+				Index:  index,
+			})
+			So(err, ShouldEqual, avro.ErrIndexMismatch)
+		})
+	})
+}
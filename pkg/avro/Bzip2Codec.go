@@ -0,0 +1,30 @@
+package avro
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"errors"
+	"io/ioutil"
+)
+
+var ErrCompressionNotSupported = errors.New("compression not supported by codec")
+
+// bzip2Codec decompresses blocks written as a bzip2 stream. The standard
+// library has no bzip2 encoder so Compress returns ErrCompressionNotSupported.
+type bzip2Codec struct{}
+
+func (bzip2Codec) Name() CompressionCodec {
+	return CompressionCodecBzip2
+}
+
+func (bzip2Codec) Compress(dst, src []byte) ([]byte, error) {
+	return nil, ErrCompressionNotSupported
+}
+
+func (codec bzip2Codec) Decompress(dst, src []byte) ([]byte, error) {
+	return codec.decompressLimit(dst, src, 0)
+}
+
+func (bzip2Codec) decompressLimit(dst, src []byte, limit int64) ([]byte, error) {
+	return readAllInto(dst, ioutil.NopCloser(bzip2.NewReader(bytes.NewReader(src))), limit)
+}
@@ -0,0 +1,209 @@
+package avro
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"sync"
+)
+
+var ErrUnknownCodec = errors.New("unknown compression codec")
+
+// Codec compresses and decompresses the data of object blocks. Codecs must be
+// safe for concurrent use.
+type Codec interface {
+	// Name is the value stored in the avro.codec header metadata.
+	Name() CompressionCodec
+	// Compress appends the compressed form of src to dst.
+	Compress(dst, src []byte) ([]byte, error)
+	// Decompress appends the decompressed form of src to dst.
+	Decompress(dst, src []byte) ([]byte, error)
+}
+
+// LevelCodec is implemented by codecs with a configurable compression level.
+type LevelCodec interface {
+	Codec
+	// WithLevel returns a copy of the codec compressing at level.
+	WithLevel(level int) (Codec, error)
+}
+
+// LegacyCodec is implemented by codecs that can also decompress blocks written
+// by earlier versions of this package.
+type LegacyCodec interface {
+	Codec
+	DecompressLegacy(dst, src []byte) ([]byte, error)
+}
+
+var codecRegistry = struct {
+	sync.RWMutex
+	codecs map[CompressionCodec]Codec
+}{
+	codecs: map[CompressionCodec]Codec{
+		CompressionCodecNull:    nullCodec{},
+		CompressionCodecDeflate: newDeflateCodec(defaultDeflateLevel),
+		CompressionCodecSnappy:  snappyCodec{},
+		CompressionCodecZstandard: &zstandardCodec{
+			level: defaultZstandardLevel,
+		},
+		CompressionCodecBzip2: bzip2Codec{},
+		CompressionCodecXZ:    xzCodec{},
+	},
+}
+
+// RegisterCodec makes codec available to readers and writers under its Name,
+// replacing any codec previously registered with that name.
+func RegisterCodec(codec Codec) {
+	codecRegistry.Lock()
+	defer codecRegistry.Unlock()
+	codecRegistry.codecs[codec.Name()] = codec
+}
+
+func LookupCodec(name CompressionCodec) (Codec, error) {
+	codecRegistry.RLock()
+	defer codecRegistry.RUnlock()
+	codec, ok := codecRegistry.codecs[name]
+	if !ok {
+		return nil, ErrUnknownCodec
+	}
+	return codec, nil
+}
+
+// checkCompresses returns the error of a codec that can't compress, such as
+// bzip2 with ErrCompressionNotSupported, so that writers fail before anything
+// is written rather than on the first block.
+func checkCompresses(codec Codec) error {
+	_, err := codec.Compress(nil, nil)
+	return err
+}
+
+// NewCodecWriter buffers everything written to it and writes it to writer,
+// compressed as a single block, on Close.
+func NewCodecWriter(writer io.Writer, name CompressionCodec) (io.WriteCloser, error) {
+	codec, err := LookupCodec(name)
+	if err != nil {
+		return nil, err
+	}
+	return &codecWriter{
+		writer: writer,
+		codec:  codec,
+	}, nil
+}
+
+// NewCodecReader reads the whole of reader and decompresses it as a single
+// block.
+func NewCodecReader(reader io.Reader, name CompressionCodec) (io.ReadCloser, error) {
+	codec, err := LookupCodec(name)
+	if err != nil {
+		return nil, err
+	}
+	return newCodecReader(reader, selectDecompressFunc(codec, false))
+}
+
+// NewLegacyCodecReader is NewCodecReader but also accepts blocks written by
+// earlier versions of this package when the codec is a LegacyCodec.
+func NewLegacyCodecReader(reader io.Reader, name CompressionCodec) (io.ReadCloser, error) {
+	codec, err := LookupCodec(name)
+	if err != nil {
+		return nil, err
+	}
+	return newCodecReader(reader, selectDecompressFunc(codec, true))
+}
+
+// decompressFunc appends the decompressed form of src to dst, returning a
+// LimitExceededError if that is more than limit bytes. Zero means no limit.
+type decompressFunc func(dst, src []byte, limit int64) ([]byte, error)
+
+// limitedCodec is implemented by the codecs of this package, which stop
+// decompressing once the limit is exceeded.
+type limitedCodec interface {
+	decompressLimit(dst, src []byte, limit int64) ([]byte, error)
+}
+
+type limitedLegacyCodec interface {
+	decompressLegacyLimit(dst, src []byte, limit int64) ([]byte, error)
+}
+
+func selectDecompressFunc(codec Codec, legacy bool) decompressFunc {
+	if legacyCodec, ok := codec.(limitedLegacyCodec); ok && legacy {
+		return legacyCodec.decompressLegacyLimit
+	}
+	if limitedCodec, ok := codec.(limitedCodec); ok {
+		return limitedCodec.decompressLimit
+	}
+	decompress := codec.Decompress
+	if legacyCodec, ok := codec.(LegacyCodec); ok && legacy {
+		decompress = legacyCodec.DecompressLegacy
+	}
+	// other codecs can only be checked once they have decompressed
+	return func(dst, src []byte, limit int64) ([]byte, error) {
+		offset := len(dst)
+		dst, err := decompress(dst, src)
+		if err != nil {
+			return nil, err
+		}
+		size := int64(len(dst) - offset)
+		if limit > 0 && size > limit {
+			return nil, decompressedBlockSizeExceeded(size, limit)
+		}
+		return dst, nil
+	}
+}
+
+// newCodecReader decompresses the whole of reader, limited by the
+// MaxDecompressedBlockSize of DefaultDecoderConfig.
+func newCodecReader(reader io.Reader, decompress decompressFunc) (io.ReadCloser, error) {
+	src, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	data, err := decompress(nil, src, DefaultDecoderConfig.MaxDecompressedBlockSize)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+type codecWriter struct {
+	writer io.Writer
+	codec  Codec
+	buffer bytes.Buffer
+}
+
+func (codecWriter *codecWriter) Write(p []byte) (n int, err error) {
+	return codecWriter.buffer.Write(p)
+}
+
+func (codecWriter *codecWriter) Close() (err error) {
+	compressed, err := codecWriter.codec.Compress(nil, codecWriter.buffer.Bytes())
+	if err != nil {
+		return
+	}
+	_, err = codecWriter.writer.Write(compressed)
+	if err != nil {
+		return
+	}
+	codecWriter.buffer.Reset()
+	return
+}
+
+type nullCodec struct{}
+
+func (nullCodec) Name() CompressionCodec {
+	return CompressionCodecNull
+}
+
+func (nullCodec) Compress(dst, src []byte) ([]byte, error) {
+	return append(dst, src...), nil
+}
+
+func (codec nullCodec) Decompress(dst, src []byte) ([]byte, error) {
+	return codec.decompressLimit(dst, src, 0)
+}
+
+func (nullCodec) decompressLimit(dst, src []byte, limit int64) ([]byte, error) {
+	if limit > 0 && int64(len(src)) > limit {
+		return nil, decompressedBlockSizeExceeded(int64(len(src)), limit)
+	}
+	return append(dst, src...), nil
+}
@@ -0,0 +1,146 @@
+package avro_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avro"
+)
+
+type xorCodec struct{}
+
+func (xorCodec) Name() avro.CompressionCodec {
+	return "x-xor"
+}
+
+func (xorCodec) Compress(dst, src []byte) ([]byte, error) {
+	for _, b := range src {
+		dst = append(dst, b^0x5a)
+	}
+	return dst, nil
+}
+
+func (codec xorCodec) Decompress(dst, src []byte) ([]byte, error) {
+	return codec.Compress(dst, src)
+}
+
+func TestCodec(t *testing.T) {
+	Convey("TestCodec", t, func() {
+		people := generatePeople(100)
+		Convey("registered codec", func() {
+			avro.RegisterCodec(xorCodec{})
+			var buffer bytes.Buffer
+			fileWriter, err := avro.NewFileWriter(avro.NewFileWriterInput{
+				Writer:           &buffer,
+				Schema:           parsePersonSchema(),
+				CompressionCodec: "x-xor",
+				MaxBlockLength:   10,
+			})
+			So(err, ShouldBeNil)
+			for i := range people {
+				err = fileWriter.Append(&people[i])
+				So(err, ShouldBeNil)
+			}
+			err = fileWriter.Close()
+			So(err, ShouldBeNil)
+			fileReader, err := avro.NewFileReader(avro.NewFileReaderInput{
+				Reader: &buffer,
+			})
+			So(err, ShouldBeNil)
+			So(fileReader.CompressionCodec(), ShouldEqual, "x-xor")
+			var actual []Person
+			var person Person
+			for fileReader.Next(&person) {
+				actual = append(actual, person)
+			}
+			So(fileReader.Err(), ShouldBeNil)
+			So(actual, ShouldResemble, people)
+		})
+		Convey("unknown codec", func() {
+			_, err := avro.LookupCodec("unknown")
+			So(err, ShouldEqual, avro.ErrUnknownCodec)
+			var buffer bytes.Buffer
+			header, err := avro.NewObjectContainerHeader(avro.NewObjectContainerHeaderInput{
+				Schema:           parsePersonSchema(),
+				CompressionCodec: "unknown",
+			})
+			So(err, ShouldBeNil)
+			err = header.WriteAvro(&buffer)
+			So(err, ShouldBeNil)
+			err = new(avro.ObjectContainerHeader).ReadAvro(&buffer)
+			So(err, ShouldEqual, avro.ErrUnknownCodec)
+		})
+		Convey("deflate level", func() {
+			codec, err := avro.LookupCodec(avro.CompressionCodecDeflate)
+			So(err, ShouldBeNil)
+			levelCodec, ok := codec.(avro.LevelCodec)
+			So(ok, ShouldBeTrue)
+			_, err = levelCodec.WithLevel(10)
+			So(err, ShouldNotBeNil)
+			codec, err = levelCodec.WithLevel(9)
+			So(err, ShouldBeNil)
+			var buffer bytes.Buffer
+			fileWriter, err := avro.NewFileWriter(avro.NewFileWriterInput{
+				Writer: &buffer,
+				Schema: parsePersonSchema(),
+				Codec:  codec,
+			})
+			So(err, ShouldBeNil)
+			for i := range people {
+				err = fileWriter.Append(&people[i])
+				So(err, ShouldBeNil)
+			}
+			err = fileWriter.Close()
+			So(err, ShouldBeNil)
+			fileReader, err := avro.NewFileReader(avro.NewFileReaderInput{
+				Reader: &buffer,
+			})
+			So(err, ShouldBeNil)
+			So(fileReader.CompressionCodec(), ShouldEqual, avro.CompressionCodecDeflate)
+			count := 0
+			var person Person
+			for fileReader.Next(&person) {
+				count++
+			}
+			So(fileReader.Err(), ShouldBeNil)
+			So(count, ShouldEqual, len(people))
+		})
+		Convey("zstandard level", func() {
+			_, err := avro.NewZstandardCodec(0)
+			So(err, ShouldNotBeNil)
+			codec, err := avro.NewZstandardCodec(19)
+			So(err, ShouldBeNil)
+			data := []byte(generateRandomString(4096))
+			compressed, err := codec.Compress(nil, data)
+			So(err, ShouldBeNil)
+			decompressed, err := codec.Decompress([]byte("prefix"), compressed)
+			So(err, ShouldBeNil)
+			So(string(decompressed), ShouldEqual, "prefix"+string(data))
+		})
+		Convey("bzip2 is decompress only", func() {
+			codec, err := avro.LookupCodec(avro.CompressionCodecBzip2)
+			So(err, ShouldBeNil)
+			_, err = codec.Compress(nil, []byte("data"))
+			So(err, ShouldEqual, avro.ErrCompressionNotSupported)
+			var buffer bytes.Buffer
+			_, err = avro.NewFileWriter(avro.NewFileWriterInput{
+				Writer:           &buffer,
+				Schema:           parsePersonSchema(),
+				CompressionCodec: avro.CompressionCodecBzip2,
+			})
+			So(err, ShouldEqual, avro.ErrCompressionNotSupported)
+			So(buffer.Len(), ShouldEqual, 0)
+			file, err := os.Open("testdata/people-bzip2.avro")
+			So(err, ShouldBeNil)
+			defer file.Close()
+			_, err = avro.NewFileAppender(avro.NewFileAppenderInput{
+				File:   file,
+				Schema: parsePersonSchema(),
+			})
+			So(err, ShouldEqual, avro.ErrCompressionNotSupported)
+		})
+	})
+}
@@ -0,0 +1,178 @@
+package avro
+
+import (
+	"bytes"
+	"errors"
+	"io"
+)
+
+var ErrEncoderClosed = errors.New("encoder closed")
+
+type NewCollectionEncoderInput struct {
+	Writer io.Writer
+	// MaxBlockLength is the maximum number of items per block, zero means no
+	// limit.
+	MaxBlockLength int64
+	// MaxBlockSize is the size in bytes at which a block is written, zero
+	// means DefaultMaxBlockSize.
+	MaxBlockSize int
+	// SizedBlocks writes blocks with their size, see WithSizedBlocks.
+	SizedBlocks bool
+}
+
+// collectionEncoder buffers the items of an array or map into blocks, which
+// are written once they reach MaxBlockLength items or MaxBlockSize bytes.
+type collectionEncoder struct {
+	writer         io.Writer
+	maxBlockLength int64
+	maxBlockSize   int
+	sizedBlocks    bool
+	block          bytes.Buffer
+	length         int64
+	closed         bool
+	err            error
+}
+
+func newCollectionEncoder(input NewCollectionEncoderInput) collectionEncoder {
+	maxBlockSize := input.MaxBlockSize
+	if maxBlockSize <= 0 {
+		maxBlockSize = DefaultMaxBlockSize
+	}
+	return collectionEncoder{
+		writer:         input.Writer,
+		maxBlockLength: input.MaxBlockLength,
+		maxBlockSize:   maxBlockSize,
+		sizedBlocks:    input.SizedBlocks,
+	}
+}
+
+// appendItem encodes an item into the current block with write. An item that
+// fails to encode is discarded without affecting previously appended items.
+func (encoder *collectionEncoder) appendItem(write func(writer Writer) error) (err error) {
+	if encoder.closed {
+		return ErrEncoderClosed
+	}
+	if encoder.err != nil {
+		return encoder.err
+	}
+	size := encoder.block.Len()
+	err = write(&encoder.block)
+	if err != nil {
+		encoder.block.Truncate(size)
+		return
+	}
+	encoder.length++
+	if encoder.maxBlockLength > 0 && encoder.length >= encoder.maxBlockLength {
+		return encoder.writeBlock()
+	}
+	if encoder.block.Len() >= encoder.maxBlockSize {
+		return encoder.writeBlock()
+	}
+	return
+}
+
+// Flush writes the current block, if it has any items.
+func (encoder *collectionEncoder) Flush() error {
+	if encoder.closed {
+		return ErrEncoderClosed
+	}
+	return encoder.writeBlock()
+}
+
+// Close writes the current block and the terminating empty block. It does
+// not close the underlying writer.
+func (encoder *collectionEncoder) Close() (err error) {
+	if encoder.closed {
+		return ErrEncoderClosed
+	}
+	err = encoder.writeBlock()
+	encoder.closed = true
+	if err != nil {
+		return
+	}
+	_, err = WriteLong(encoder.writer, 0)
+	return
+}
+
+func (encoder *collectionEncoder) writeBlock() (err error) {
+	if encoder.err != nil {
+		return encoder.err
+	}
+	if encoder.length == 0 {
+		return
+	}
+	defer func() {
+		encoder.err = err
+	}()
+	if encoder.sizedBlocks {
+		_, err = writeSizedBlock(encoder.writer, encoder.length, encoder.block.Bytes())
+	} else {
+		_, err = WriteLong(encoder.writer, encoder.length)
+		if err == nil {
+			_, err = encoder.writer.Write(encoder.block.Bytes())
+		}
+	}
+	if err != nil {
+		return
+	}
+	encoder.block.Reset()
+	encoder.length = 0
+	return
+}
+
+// ArrayEncoder writes an array whose length is not known up front as a
+// sequence of blocks.
+type ArrayEncoder struct {
+	collectionEncoder
+}
+
+func NewArrayEncoder(input NewCollectionEncoderInput) *ArrayEncoder {
+	return &ArrayEncoder{
+		collectionEncoder: newCollectionEncoder(input),
+	}
+}
+
+func (encoder *ArrayEncoder) Append(value Marshaler) error {
+	return encoder.AppendFunc(func(writer Writer) (int, error) {
+		return value.WriteAvro(writer)
+	})
+}
+
+// AppendFunc appends an item encoded by write, e.g. with WriteLong.
+func (encoder *ArrayEncoder) AppendFunc(write func(writer Writer) (int, error)) error {
+	return encoder.appendItem(func(writer Writer) error {
+		_, err := write(writer)
+		return err
+	})
+}
+
+// MapEncoder writes a map whose length is not known up front as a sequence of
+// blocks.
+type MapEncoder struct {
+	collectionEncoder
+}
+
+func NewMapEncoder(input NewCollectionEncoderInput) *MapEncoder {
+	return &MapEncoder{
+		collectionEncoder: newCollectionEncoder(input),
+	}
+}
+
+func (encoder *MapEncoder) Append(key string, value Marshaler) error {
+	return encoder.AppendFunc(key, func(writer Writer) (int, error) {
+		return value.WriteAvro(writer)
+	})
+}
+
+// AppendFunc appends an entry whose value is encoded by write, e.g. with
+// WriteString.
+func (encoder *MapEncoder) AppendFunc(key string, write func(writer Writer) (int, error)) error {
+	return encoder.appendItem(func(writer Writer) error {
+		_, err := WriteString(writer, key)
+		if err != nil {
+			return err
+		}
+		_, err = write(writer)
+		return err
+	})
+}
@@ -0,0 +1,111 @@
+package avro_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avro"
+)
+
+func readBlockCounts(data []byte) (counts []int64) {
+	reader := bytes.NewReader(data)
+	for {
+		count, size, err := avro.ReadCollectionBlockHeader(reader)
+		So(err, ShouldBeNil)
+		counts = append(counts, count)
+		if count == 0 {
+			return
+		}
+		So(size, ShouldBeGreaterThan, 0)
+		_, err = reader.Seek(size, io.SeekCurrent)
+		So(err, ShouldBeNil)
+	}
+}
+
+func TestCollectionEncoder(t *testing.T) {
+	Convey("TestCollectionEncoder", t, func() {
+		var buffer bytes.Buffer
+		Convey("array", func() {
+			people := generatePeople(25)
+			encoder := avro.NewArrayEncoder(avro.NewCollectionEncoderInput{
+				Writer:         &buffer,
+				MaxBlockLength: 10,
+				SizedBlocks:    true,
+			})
+			for i := range people {
+				err := encoder.Append(&people[i])
+				So(err, ShouldBeNil)
+			}
+			err := encoder.Close()
+			So(err, ShouldBeNil)
+			err = encoder.Append(&people[0])
+			So(err, ShouldEqual, avro.ErrEncoderClosed)
+			So(readBlockCounts(buffer.Bytes()), ShouldResemble, []int64{10, 10, 5, 0})
+			var actual []Person
+			err = avro.ReadArray(&buffer, func(int) error {
+				var person Person
+				err := person.ReadAvro(&buffer)
+				actual = append(actual, person)
+				return err
+			})
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, people)
+			So(buffer.Len(), ShouldEqual, 0)
+		})
+		Convey("array by size", func() {
+			encoder := avro.NewArrayEncoder(avro.NewCollectionEncoderInput{
+				Writer:       &buffer,
+				MaxBlockSize: 16,
+			})
+			expected := make([]int64, 100)
+			for i := range expected {
+				expected[i] = int64(i) << 20
+				err := encoder.AppendFunc(func(writer avro.Writer) (int, error) {
+					return avro.WriteLong(writer, expected[i])
+				})
+				So(err, ShouldBeNil)
+			}
+			err := encoder.Close()
+			So(err, ShouldBeNil)
+			var actual []int64
+			err = avro.ReadLongSlice(&buffer, &actual)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, expected)
+		})
+		Convey("map", func() {
+			encoder := avro.NewMapEncoder(avro.NewCollectionEncoderInput{
+				Writer:         &buffer,
+				MaxBlockLength: 2,
+			})
+			expected := map[string]string{
+				"x": generateRandomString(8),
+				"y": generateRandomString(8),
+				"z": generateRandomString(8),
+			}
+			for key, value := range expected {
+				value := value
+				err := encoder.AppendFunc(key, func(writer avro.Writer) (int, error) {
+					return avro.WriteString(writer, value)
+				})
+				So(err, ShouldBeNil)
+			}
+			err := encoder.Close()
+			So(err, ShouldBeNil)
+			var actual map[string]string
+			err = avro.ReadStringMap(&buffer, &actual)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, expected)
+			So(buffer.Len(), ShouldEqual, 0)
+		})
+		Convey("empty", func() {
+			err := avro.NewArrayEncoder(avro.NewCollectionEncoderInput{
+				Writer: &buffer,
+			}).Close()
+			So(err, ShouldBeNil)
+			So(buffer.Bytes(), ShouldResemble, []byte{0})
+		})
+	})
+}
@@ -0,0 +1,98 @@
+package avro
+
+import (
+	"bytes"
+	"io"
+)
+
+type collectionOptions struct {
+	sizedBlocks bool
+}
+
+// CollectionOption configures how arrays and maps are written.
+type CollectionOption func(options *collectionOptions)
+
+// WithSizedBlocks writes each block as a negative item count followed by the
+// size of the block in bytes, so readers can skip it without decoding the
+// items. The items of each block are buffered to compute its size.
+func WithSizedBlocks() CollectionOption {
+	return func(options *collectionOptions) {
+		options.sizedBlocks = true
+	}
+}
+
+func newCollectionOptions(options []CollectionOption) (collectionOptions collectionOptions) {
+	for _, option := range options {
+		option(&collectionOptions)
+	}
+	return
+}
+
+// writeCollection writes length items with writeItems as a single block
+// followed by the terminating empty block.
+func writeCollection(writer io.Writer, length int, options []CollectionOption, writeItems func(writer Writer) (int, error)) (nTotal int, err error) {
+	var n int
+	if length == 0 {
+		return WriteLong(writer, 0)
+	}
+	if newCollectionOptions(options).sizedBlocks {
+		var block bytes.Buffer
+		_, err = writeItems(&block)
+		if err != nil {
+			return
+		}
+		n, err = writeSizedBlock(writer, int64(length), block.Bytes())
+		nTotal += n
+		if err != nil {
+			return
+		}
+	} else {
+		n, err = WriteLong(writer, int64(length))
+		nTotal += n
+		if err != nil {
+			return
+		}
+		n, err = writeItems(asWriter(writer))
+		nTotal += n
+		if err != nil {
+			return
+		}
+	}
+	n, err = WriteLong(writer, 0)
+	nTotal += n
+	return
+}
+
+// writeSizedBlock writes a block of length items encoded in data with a
+// negative count and the size of data.
+func writeSizedBlock(writer io.Writer, length int64, data []byte) (nTotal int, err error) {
+	n, err := WriteLong(writer, -length)
+	nTotal += n
+	if err != nil {
+		return
+	}
+	n, err = WriteLong(writer, int64(len(data)))
+	nTotal += n
+	if err != nil {
+		return
+	}
+	n, err = writer.Write(data)
+	nTotal += n
+	return
+}
+
+func asWriter(writer io.Writer) Writer {
+	if writer, ok := writer.(Writer); ok {
+		return writer
+	}
+	return byteWriter{writer}
+}
+
+type byteWriter struct {
+	io.Writer
+}
+
+func (writer byteWriter) WriteByte(c byte) error {
+	_, err := writer.Write([]byte{c})
+	return err
+}
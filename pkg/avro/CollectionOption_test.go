@@ -0,0 +1,95 @@
+package avro_test
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avro"
+)
+
+func TestSizedBlocks(t *testing.T) {
+	Convey("TestSizedBlocks", t, func() {
+		var buffer bytes.Buffer
+		Convey("map", func() {
+			expected := map[string]string{
+				"x": generateRandomString(8),
+				"y": generateRandomString(8),
+				"z": generateRandomString(8),
+			}
+			_, err := avro.WriteStringMap(&buffer, expected, avro.WithSizedBlocks())
+			So(err, ShouldBeNil)
+			data := append([]byte(nil), buffer.Bytes()...)
+			count, size, err := avro.ReadCollectionBlockHeader(bytes.NewReader(data))
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 3)
+			So(size, ShouldEqual, 3*(2+9))
+			var actual map[string]string
+			err = avro.ReadStringMap(&buffer, &actual)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, expected)
+			So(buffer.Len(), ShouldEqual, 0)
+		})
+		Convey("array", func() {
+			people := generatePeople(5)
+			_, err := avro.WriteArrayTo(&buffer, len(people), func(writer avro.Writer, i int) (int, error) {
+				return people[i].WriteAvro(writer)
+			}, avro.WithSizedBlocks())
+			So(err, ShouldBeNil)
+			actual := make([]Person, 0)
+			err = avro.ReadArray(&buffer, func(int) error {
+				var person Person
+				err := person.ReadAvro(&buffer)
+				actual = append(actual, person)
+				return err
+			})
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, people)
+			So(buffer.Len(), ShouldEqual, 0)
+		})
+		Convey("mixed blocks", func() {
+			var block bytes.Buffer
+			for _, value := range []int64{1, 2} {
+				_, err := avro.WriteLong(&block, value)
+				So(err, ShouldBeNil)
+			}
+			_, err := avro.WriteLong(&buffer, -2)
+			So(err, ShouldBeNil)
+			_, err = avro.WriteBytes(&buffer, block.Bytes())
+			So(err, ShouldBeNil)
+			for _, value := range []int64{1, 3, 0} {
+				_, err = avro.WriteLong(&buffer, value)
+				So(err, ShouldBeNil)
+			}
+			var slice []int64
+			err = avro.ReadLongSlice(&buffer, &slice)
+			So(err, ShouldBeNil)
+			So(slice, ShouldResemble, []int64{1, 2, 3})
+			for _, value := range []float64{1.5, 2.5} {
+				_, err = avro.WriteLong(&buffer, 1)
+				So(err, ShouldBeNil)
+				_, err = avro.WriteDouble(&buffer, value)
+				So(err, ShouldBeNil)
+			}
+			_, err = avro.WriteLong(&buffer, 0)
+			So(err, ShouldBeNil)
+			values := make([]float64, 2)
+			err = avro.ReadDoubleArray(&buffer, values)
+			So(err, ShouldBeNil)
+			So(values, ShouldResemble, []float64{1.5, 2.5})
+			for _, value := range []int64{-1, 1, 4, 1, 5, 0} {
+				_, err = avro.WriteLong(&buffer, value)
+				So(err, ShouldBeNil)
+			}
+			var indexes []int
+			err = avro.ReadArray(&buffer, func(i int) error {
+				indexes = append(indexes, i)
+				var value int64
+				return avro.ReadLong(&buffer, &value)
+			})
+			So(err, ShouldBeNil)
+			So(indexes, ShouldResemble, []int{0, 1})
+		})
+	})
+}
@@ -0,0 +1,152 @@
+package avro
+
+import (
+	"bufio"
+	"sync"
+)
+
+// compressionPipeline compresses blocks on a pool of worker goroutines and
+// writes them in order from a single writer goroutine. At most maxPending
+// blocks are buffered, after which write blocks until one has been written.
+type compressionPipeline struct {
+	writer  *bufio.Writer
+	codec   Codec
+	sync    [16]byte
+	jobs    chan *compressionJob
+	pending chan *compressionJob
+	buffers sync.Pool
+	stopped chan struct{}
+	// written is called from the writer goroutine after each block is written.
+	written func(length int64, nWritten int)
+	mutex   sync.Mutex
+	err     error
+}
+
+type compressionJob struct {
+	length     int64
+	data       []byte
+	compressed []byte
+	err        error
+	ready      chan struct{}
+	// flushed is set for jobs requesting that the writer be flushed.
+	flushed chan error
+}
+
+func newCompressionPipeline(writer *bufio.Writer, codec Codec, sync [16]byte, workers int, maxPending int, written func(length int64, nWritten int)) *compressionPipeline {
+	if maxPending <= 0 {
+		maxPending = 2 * workers
+	}
+	pipeline := &compressionPipeline{
+		writer:  writer,
+		codec:   codec,
+		sync:    sync,
+		jobs:    make(chan *compressionJob, maxPending),
+		pending: make(chan *compressionJob, maxPending),
+		stopped: make(chan struct{}),
+		written: written,
+	}
+	for i := 0; i < workers; i++ {
+		go pipeline.compressBlocks()
+	}
+	go pipeline.writeBlocks()
+	return pipeline
+}
+
+// write queues a copy of data to be compressed and written as a block of
+// length records, returning the first error from an earlier block.
+func (pipeline *compressionPipeline) write(length int64, data []byte) error {
+	err := pipeline.getErr()
+	if err != nil {
+		return err
+	}
+	job := &compressionJob{
+		length: length,
+		data:   append(pipeline.getBuffer(), data...),
+		ready:  make(chan struct{}),
+	}
+	pipeline.pending <- job
+	pipeline.jobs <- job
+	return nil
+}
+
+// flush waits for the queued blocks to be written and flushes the writer.
+func (pipeline *compressionPipeline) flush() error {
+	job := &compressionJob{
+		ready:   make(chan struct{}),
+		flushed: make(chan error, 1),
+	}
+	close(job.ready)
+	pipeline.pending <- job
+	return <-job.flushed
+}
+
+func (pipeline *compressionPipeline) close() error {
+	err := pipeline.flush()
+	close(pipeline.jobs)
+	close(pipeline.pending)
+	<-pipeline.stopped
+	return err
+}
+
+func (pipeline *compressionPipeline) compressBlocks() {
+	for job := range pipeline.jobs {
+		job.compressed, job.err = pipeline.codec.Compress(pipeline.getBuffer(), job.data)
+		close(job.ready)
+	}
+}
+
+func (pipeline *compressionPipeline) writeBlocks() {
+	defer close(pipeline.stopped)
+	for job := range pipeline.pending {
+		<-job.ready
+		if job.flushed != nil {
+			err := pipeline.getErr()
+			if err == nil {
+				err = pipeline.writer.Flush()
+				pipeline.setErr(err)
+			}
+			job.flushed <- err
+			continue
+		}
+		err := job.err
+		if err == nil && pipeline.getErr() == nil {
+			var n int
+			n, err = writeObjectBlockData(pipeline.writer, job.length, job.compressed, pipeline.sync)
+			if err == nil {
+				pipeline.written(job.length, n)
+			}
+		}
+		pipeline.setErr(err)
+		pipeline.putBuffer(job.data)
+		pipeline.putBuffer(job.compressed)
+	}
+}
+
+func (pipeline *compressionPipeline) getBuffer() []byte {
+	buffer, ok := pipeline.buffers.Get().([]byte)
+	if !ok {
+		return nil
+	}
+	return buffer[:0]
+}
+
+func (pipeline *compressionPipeline) putBuffer(buffer []byte) {
+	if buffer != nil {
+		pipeline.buffers.Put(buffer)
+	}
+}
+
+func (pipeline *compressionPipeline) getErr() error {
+	pipeline.mutex.Lock()
+	defer pipeline.mutex.Unlock()
+	return pipeline.err
+}
+
+// setErr records err unless an earlier error has already been recorded.
+func (pipeline *compressionPipeline) setErr(err error) {
+	pipeline.mutex.Lock()
+	defer pipeline.mutex.Unlock()
+	if pipeline.err == nil {
+		pipeline.err = err
+	}
+}
@@ -0,0 +1,136 @@
+package avro
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avroschema"
+)
+
+type ConcatInput struct {
+	Writer  io.Writer
+	Readers []io.Reader
+	// CompressionCodec is the codec of the output, the codec of the first
+	// file is used when empty.
+	CompressionCodec CompressionCodec
+	// Codec takes precedence over CompressionCodec.
+	Codec Codec
+	// Sync is generated with GenerateSync when left as the zero value.
+	Sync [16]byte
+}
+
+// Concat writes the blocks of the object container files read from
+// input.Readers to input.Writer as a single file. Every file must have the
+// same schema as the first, whose metadata is copied to the output header.
+// Blocks of files with the output codec are copied without being decoded,
+// only their sync markers are rewritten. Blocks of other files are
+// decompressed and compressed with the output codec.
+func Concat(input ConcatInput) (err error) {
+	if len(input.Readers) == 0 {
+		return errors.New("no files to concatenate")
+	}
+	writer := bufio.NewWriter(input.Writer)
+	var header *ObjectContainerHeader
+	var schema avroschema.Schema
+	codec := input.Codec
+	if codec == nil && input.CompressionCodec != "" {
+		codec, err = LookupCodec(input.CompressionCodec)
+		if err != nil {
+			return
+		}
+	}
+	for i, reader := range input.Readers {
+		bufferedReader := bufio.NewReader(reader)
+		fileHeader := new(ObjectContainerHeader)
+		err = fileHeader.ReadAvro(bufferedReader)
+		if err != nil {
+			return fmt.Errorf("file %d: %w", i, err)
+		}
+		if header == nil {
+			schema, err = fileHeader.Schema()
+			if err != nil {
+				return fmt.Errorf("file %d: %w", i, err)
+			}
+			if codec == nil {
+				codec, err = fileHeader.Codec()
+				if err != nil {
+					return
+				}
+			}
+			header, err = writeConcatHeader(writer, fileHeader, codec, input.Sync)
+			if err != nil {
+				return
+			}
+		} else {
+			err = checkSchemaMatches(fileHeader, schema)
+			if err != nil {
+				return fmt.Errorf("file %d: %w", i, err)
+			}
+		}
+		err = concatBlocks(writer, header, codec, bufferedReader, fileHeader)
+		if err != nil {
+			return fmt.Errorf("file %d: %w", i, err)
+		}
+	}
+	return writer.Flush()
+}
+
+func writeConcatHeader(writer io.Writer, fileHeader *ObjectContainerHeader, codec Codec, sync [16]byte) (header *ObjectContainerHeader, err error) {
+	if sync == [16]byte{} {
+		sync = GenerateSync()
+	}
+	header = &ObjectContainerHeader{
+		Meta: make(map[string][]byte, len(fileHeader.Meta)),
+		Sync: sync,
+	}
+	for key, value := range fileHeader.Meta {
+		header.Meta[key] = value
+	}
+	header.Meta["avro.codec"] = []byte(codec.Name())
+	err = header.WriteAvro(writer)
+	return
+}
+
+// concatBlocks copies the blocks read from reader, which follow fileHeader,
+// to writer with the sync marker of header.
+func concatBlocks(writer io.Writer, header *ObjectContainerHeader, codec Codec, reader Reader, fileHeader *ObjectContainerHeader) (err error) {
+	var fileCodec Codec
+	if fileHeader.CompressionCodec() != codec.Name() {
+		fileCodec, err = fileHeader.Codec()
+		if err != nil {
+			return
+		}
+	}
+	blockIterator := NewObjectBlockIterator(NewObjectBlockIteratorInput{
+		Reader:       reader,
+		ExpectedSync: fileHeader.Sync,
+	})
+	var decompress decompressFunc
+	if fileCodec != nil {
+		decompress = selectDecompressFunc(fileCodec, false)
+	}
+	limit := DecoderConfigOf(reader).MaxDecompressedBlockSize
+	var block ObjectBlock
+	var decompressed, compressed []byte
+	for blockIterator.Next(&block) {
+		data := block.Bytes()
+		if fileCodec != nil {
+			decompressed, err = decompress(decompressed[:0], data, limit)
+			if err != nil {
+				return
+			}
+			compressed, err = codec.Compress(compressed[:0], decompressed)
+			if err != nil {
+				return
+			}
+			data = compressed
+		}
+		_, err = writeObjectBlockData(writer, block.Length, data, header.Sync)
+		if err != nil {
+			return
+		}
+	}
+	return blockIterator.Err()
+}
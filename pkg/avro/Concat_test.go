@@ -0,0 +1,117 @@
+package avro_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avro"
+	"github.com/Ryan-A-B/avro-go/pkg/avroschema"
+)
+
+func readPeople(files ...[]byte) (people []Person) {
+	for _, file := range files {
+		fileReader, err := avro.NewFileReader(avro.NewFileReaderInput{
+			Reader: bytes.NewReader(file),
+		})
+		So(err, ShouldBeNil)
+		var person Person
+		for fileReader.Next(&person) {
+			people = append(people, person)
+		}
+		So(fileReader.Err(), ShouldBeNil)
+	}
+	return
+}
+
+func readBlocks(file []byte) (blocks [][]byte) {
+	reader := bytes.NewReader(file)
+	var header avro.ObjectContainerHeader
+	err := header.ReadAvro(reader)
+	So(err, ShouldBeNil)
+	blockIterator := avro.NewObjectBlockIterator(avro.NewObjectBlockIteratorInput{
+		Reader:       reader,
+		ExpectedSync: header.Sync,
+	})
+	var block avro.ObjectBlock
+	for blockIterator.Next(&block) {
+		blocks = append(blocks, append([]byte(nil), block.Bytes()...))
+	}
+	So(blockIterator.Err(), ShouldBeNil)
+	return
+}
+
+func TestConcat(t *testing.T) {
+	Convey("TestConcat", t, func() {
+		files := [][]byte{
+			writeTestFile(avro.CompressionCodecDeflate, 3, 10).data,
+			writeTestFile(avro.CompressionCodecDeflate, 5, 5).data,
+			writeTestFile(avro.CompressionCodecSnappy, 7, 5).data,
+		}
+		readers := func(files ...[]byte) []io.Reader {
+			readers := make([]io.Reader, len(files))
+			for i := range files {
+				readers[i] = bytes.NewReader(files[i])
+			}
+			return readers
+		}
+		Convey("copies blocks with matching codec", func() {
+			var buffer bytes.Buffer
+			err := avro.Concat(avro.ConcatInput{
+				Writer:  &buffer,
+				Readers: readers(files[:2]...),
+			})
+			So(err, ShouldBeNil)
+			expected := append(readBlocks(files[0]), readBlocks(files[1])...)
+			So(readBlocks(buffer.Bytes()), ShouldResemble, expected)
+		})
+		Convey("recompresses blocks with other codecs", func() {
+			var buffer bytes.Buffer
+			err := avro.Concat(avro.ConcatInput{
+				Writer:  &buffer,
+				Readers: readers(files...),
+			})
+			So(err, ShouldBeNil)
+			fileReader, err := avro.NewFileReader(avro.NewFileReaderInput{
+				Reader: &buffer,
+			})
+			So(err, ShouldBeNil)
+			So(fileReader.CompressionCodec(), ShouldEqual, avro.CompressionCodecDeflate)
+			var actual []Person
+			var person Person
+			for fileReader.Next(&person) {
+				actual = append(actual, person)
+			}
+			So(fileReader.Err(), ShouldBeNil)
+			So(actual, ShouldResemble, readPeople(files...))
+		})
+		Convey("schema mismatch", func() {
+			schema, err := avroschema.ParseSchema([]byte(`{
+				"type": "record",
+				"name": "Person",
+				"fields": [
+					{"name": "name", "type": "string"}
+				]
+			}`))
+			So(err, ShouldBeNil)
+			header, err := avro.NewObjectContainerHeader(avro.NewObjectContainerHeaderInput{
+				Schema:           schema,
+				CompressionCodec: avro.CompressionCodecDeflate,
+				Sync:             avro.GenerateSync(),
+			})
+			So(err, ShouldBeNil)
+			var other bytes.Buffer
+			err = header.WriteAvro(&other)
+			So(err, ShouldBeNil)
+			err = avro.Concat(avro.ConcatInput{
+				Writer:  ioutil.Discard,
+				Readers: readers(files[0], other.Bytes()),
+			})
+			So(errors.Is(err, avro.ErrSchemaMismatch), ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,244 @@
+package avro
+
+import (
+	"bytes"
+	"io"
+	"runtime"
+	"sync"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avroschema"
+)
+
+// ConcurrentFileReader reads the records of an object container file,
+// decompressing and decoding blocks on a pool of worker goroutines while
+// returning records in file order.
+type ConcurrentFileReader struct {
+	fileReader *FileReader
+	newValue   func() Unmarshaler
+	recover    func(corruption Corruption)
+	jobs       chan *decodedBlock
+	results    chan *decodedBlock
+	done       chan struct{}
+	closeOnce  sync.Once
+	iterateErr error
+	current    *decodedBlock
+	index      int
+	value      Unmarshaler
+	err        error
+}
+
+type NewConcurrentFileReaderInput struct {
+	Reader io.Reader
+	// NewValue returns the value each record is decoded into.
+	NewValue func() Unmarshaler
+	// Workers is the number of goroutines decoding blocks, zero means
+	// runtime.GOMAXPROCS.
+	Workers int
+	// MaxInFlightBlocks bounds the number of blocks read ahead of the record
+	// being returned, zero means twice Workers.
+	MaxInFlightBlocks int
+	// Legacy and Recover are as for NewFileReaderInput. Recover is always
+	// called from the goroutine calling Next.
+	Legacy  bool
+	Recover func(corruption Corruption)
+	// DecoderConfig is as for NewFileReaderInput.
+	DecoderConfig *DecoderConfig
+}
+
+// decodedBlock is a block passed from the goroutine reading blocks, through a
+// worker, to Next. Corruptions found while reading blocks are passed along in
+// order as a decodedBlock with only corruption set.
+type decodedBlock struct {
+	block      *ObjectBlock
+	start      int64
+	end        int64
+	values     []Unmarshaler
+	corruption *Corruption
+	err        error
+	ready      chan struct{}
+}
+
+func NewConcurrentFileReader(input NewConcurrentFileReaderInput) (concurrentFileReader *ConcurrentFileReader, err error) {
+	workers := input.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	maxInFlightBlocks := input.MaxInFlightBlocks
+	if maxInFlightBlocks <= 0 {
+		maxInFlightBlocks = 2 * workers
+	}
+	concurrentFileReader = &ConcurrentFileReader{
+		newValue: input.NewValue,
+		recover:  input.Recover,
+		jobs:     make(chan *decodedBlock, maxInFlightBlocks),
+		results:  make(chan *decodedBlock, maxInFlightBlocks),
+		done:     make(chan struct{}),
+	}
+	var recover func(corruption Corruption)
+	if input.Recover != nil {
+		recover = concurrentFileReader.forwardCorruption
+	}
+	concurrentFileReader.fileReader, err = NewFileReader(NewFileReaderInput{
+		Reader:        input.Reader,
+		Legacy:        input.Legacy,
+		Recover:       recover,
+		DecoderConfig: input.DecoderConfig,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < workers; i++ {
+		go concurrentFileReader.decodeBlocks()
+	}
+	go concurrentFileReader.readBlocks()
+	return
+}
+
+func (concurrentFileReader *ConcurrentFileReader) Header() *ObjectContainerHeader {
+	return concurrentFileReader.fileReader.Header()
+}
+
+func (concurrentFileReader *ConcurrentFileReader) Schema() avroschema.Schema {
+	return concurrentFileReader.fileReader.Schema()
+}
+
+func (concurrentFileReader *ConcurrentFileReader) Codec() Codec {
+	return concurrentFileReader.fileReader.Codec()
+}
+
+// Next advances to the next record, returning false when there are no more
+// records or an error occurred.
+func (concurrentFileReader *ConcurrentFileReader) Next() bool {
+	if concurrentFileReader.err != nil {
+		return false
+	}
+	select {
+	case <-concurrentFileReader.done:
+		return false
+	default:
+	}
+	for {
+		current := concurrentFileReader.current
+		if current != nil && concurrentFileReader.index < len(current.values) {
+			concurrentFileReader.value = current.values[concurrentFileReader.index]
+			current.values[concurrentFileReader.index] = nil
+			concurrentFileReader.index++
+			return true
+		}
+		current, ok := <-concurrentFileReader.results
+		if !ok {
+			concurrentFileReader.current = nil
+			concurrentFileReader.err = concurrentFileReader.iterateErr
+			return false
+		}
+		<-current.ready
+		concurrentFileReader.current = current
+		concurrentFileReader.index = 0
+		if current.corruption != nil {
+			concurrentFileReader.recover(*current.corruption)
+			continue
+		}
+		if current.err != nil {
+			if concurrentFileReader.recover == nil {
+				concurrentFileReader.err = current.err
+				concurrentFileReader.Close()
+				return false
+			}
+			concurrentFileReader.recover(Corruption{
+				Start: current.start,
+				End:   current.end,
+				Err:   current.err,
+			})
+		}
+	}
+}
+
+// Value returns the record read by the last call to Next. Each record is a
+// separate value returned by NewValue.
+func (concurrentFileReader *ConcurrentFileReader) Value() Unmarshaler {
+	return concurrentFileReader.value
+}
+
+func (concurrentFileReader *ConcurrentFileReader) Err() error {
+	return concurrentFileReader.err
+}
+
+// Close stops the goroutines reading and decoding blocks. It does not close
+// the underlying reader.
+func (concurrentFileReader *ConcurrentFileReader) Close() error {
+	concurrentFileReader.closeOnce.Do(func() {
+		close(concurrentFileReader.done)
+	})
+	return nil
+}
+
+func (concurrentFileReader *ConcurrentFileReader) readBlocks() {
+	defer close(concurrentFileReader.results)
+	defer close(concurrentFileReader.jobs)
+	blockIterator := concurrentFileReader.fileReader.blockIterator
+	for {
+		block := new(ObjectBlock)
+		if !blockIterator.Next(block) {
+			concurrentFileReader.iterateErr = blockIterator.Err()
+			return
+		}
+		job := &decodedBlock{
+			block: block,
+			start: blockIterator.BlockOffset(),
+			end:   blockIterator.Offset(),
+			ready: make(chan struct{}),
+		}
+		if !concurrentFileReader.send(concurrentFileReader.results, job) {
+			return
+		}
+		if !concurrentFileReader.send(concurrentFileReader.jobs, job) {
+			return
+		}
+	}
+}
+
+func (concurrentFileReader *ConcurrentFileReader) forwardCorruption(corruption Corruption) {
+	result := &decodedBlock{
+		corruption: &corruption,
+		ready:      make(chan struct{}),
+	}
+	close(result.ready)
+	concurrentFileReader.send(concurrentFileReader.results, result)
+}
+
+func (concurrentFileReader *ConcurrentFileReader) send(ch chan<- *decodedBlock, block *decodedBlock) bool {
+	select {
+	case ch <- block:
+		return true
+	case <-concurrentFileReader.done:
+		return false
+	}
+}
+
+func (concurrentFileReader *ConcurrentFileReader) decodeBlocks() {
+	decompress := concurrentFileReader.fileReader.decompress
+	limit := concurrentFileReader.fileReader.config.MaxDecompressedBlockSize
+	var decompressed []byte
+	for job := range concurrentFileReader.jobs {
+		decompressed, job.err = decompress(decompressed[:0], job.block.Bytes(), limit)
+		if job.err == nil {
+			job.values, job.err = concurrentFileReader.decodeValues(decompressed, job.block.Length)
+		}
+		job.block = nil
+		close(job.ready)
+	}
+}
+
+func (concurrentFileReader *ConcurrentFileReader) decodeValues(data []byte, length int64) (values []Unmarshaler, err error) {
+	records := NewDecoder(bytes.NewReader(data), concurrentFileReader.fileReader.config)
+	values = make([]Unmarshaler, 0, initialCapacity(length))
+	for i := int64(0); i < length; i++ {
+		value := concurrentFileReader.newValue()
+		err = value.ReadAvro(records)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return
+}
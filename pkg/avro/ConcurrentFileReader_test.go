@@ -0,0 +1,110 @@
+package avro_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avro"
+)
+
+func TestConcurrentFileReader(t *testing.T) {
+	Convey("TestConcurrentFileReader", t, func() {
+		newPerson := func() avro.Unmarshaler {
+			return new(Person)
+		}
+		Convey("matches FileReader", func() {
+			var name string
+			Convey("null", func() {
+				name = "testdata/people-null.avro"
+			})
+			Convey("deflate", func() {
+				name = "testdata/people-deflate.avro"
+			})
+			Convey("zstandard", func() {
+				name = "testdata/people-zstandard.avro"
+			})
+			data, err := ioutil.ReadFile(name)
+			So(err, ShouldBeNil)
+			fileReader, err := avro.NewFileReader(avro.NewFileReaderInput{
+				Reader: bytes.NewReader(data),
+			})
+			So(err, ShouldBeNil)
+			var expected []Person
+			var person Person
+			for fileReader.Next(&person) {
+				expected = append(expected, person)
+			}
+			So(fileReader.Err(), ShouldBeNil)
+			for _, workers := range []int{1, 3, 8} {
+				concurrentFileReader, err := avro.NewConcurrentFileReader(avro.NewConcurrentFileReaderInput{
+					Reader:            bytes.NewReader(data),
+					NewValue:          newPerson,
+					Workers:           workers,
+					MaxInFlightBlocks: 2,
+				})
+				So(err, ShouldBeNil)
+				var actual []Person
+				for concurrentFileReader.Next() {
+					actual = append(actual, *concurrentFileReader.Value().(*Person))
+				}
+				So(concurrentFileReader.Err(), ShouldBeNil)
+				So(actual, ShouldResemble, expected)
+			}
+		})
+		Convey("close early", func() {
+			file := writeTestFile(avro.CompressionCodecDeflate, 20, 10)
+			concurrentFileReader, err := avro.NewConcurrentFileReader(avro.NewConcurrentFileReaderInput{
+				Reader:            bytes.NewReader(file.data),
+				NewValue:          newPerson,
+				MaxInFlightBlocks: 1,
+			})
+			So(err, ShouldBeNil)
+			So(concurrentFileReader.Next(), ShouldBeTrue)
+			err = concurrentFileReader.Close()
+			So(err, ShouldBeNil)
+			So(concurrentFileReader.Next(), ShouldBeFalse)
+		})
+		Convey("error", func() {
+			file := writeTestFile(avro.CompressionCodecSnappy, 10, 10)
+			file.data[file.blockOffsets[5]+10]++
+			concurrentFileReader, err := avro.NewConcurrentFileReader(avro.NewConcurrentFileReaderInput{
+				Reader:   bytes.NewReader(file.data),
+				NewValue: newPerson,
+			})
+			So(err, ShouldBeNil)
+			count := 0
+			for concurrentFileReader.Next() {
+				count++
+			}
+			So(concurrentFileReader.Err(), ShouldNotBeNil)
+			So(count, ShouldEqual, 50)
+		})
+		Convey("recover", func() {
+			file := writeTestFile(avro.CompressionCodecSnappy, 10, 10)
+			file.data[file.blockOffsets[5]+10]++
+			file.data[file.blockOffsets[8]-1]++
+			var corruptions []avro.Corruption
+			concurrentFileReader, err := avro.NewConcurrentFileReader(avro.NewConcurrentFileReaderInput{
+				Reader:   bytes.NewReader(file.data),
+				NewValue: newPerson,
+				Recover: func(corruption avro.Corruption) {
+					corruptions = append(corruptions, corruption)
+				},
+			})
+			So(err, ShouldBeNil)
+			count := 0
+			for concurrentFileReader.Next() {
+				count++
+			}
+			So(concurrentFileReader.Err(), ShouldBeNil)
+			So(count, ShouldEqual, 70)
+			So(corruptions, ShouldResemble, []avro.Corruption{
+				{Start: file.blockOffsets[5], End: file.blockOffsets[6], Err: corruptions[0].Err},
+				{Start: file.blockOffsets[7], End: file.blockOffsets[9], Err: corruptions[1].Err},
+			})
+		})
+	})
+}
@@ -0,0 +1,209 @@
+package avro
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrNegativeLength = errors.New("negative length")
+
+// LimitExceededError is returned when a length, count or depth read from avro
+// data exceeds the DecoderConfig field named by Limit.
+type LimitExceededError struct {
+	Limit string
+	Value int64
+	Max   int64
+}
+
+func (err *LimitExceededError) Error() string {
+	return fmt.Sprintf("%d exceeds %s of %d", err.Value, err.Limit, err.Max)
+}
+
+// DecoderConfig limits what is read from avro data so that corrupt or
+// hostile input returns an error rather than panicking or exhausting memory.
+// Zero fields mean no limit.
+type DecoderConfig struct {
+	// MaxBytesLength is the maximum length of bytes and strings.
+	MaxBytesLength int64
+	// MaxItemCount is the maximum number of items in an array or map, and of
+	// records in an object container block.
+	MaxItemCount int64
+	// MaxBlockSize is the maximum size in bytes of an object container block.
+	MaxBlockSize int64
+	// MaxDecompressedBlockSize is the maximum size in bytes of an object
+	// container block once decompressed. Decompression stops once it is
+	// exceeded, so a small block cannot expand to exhaust memory.
+	MaxDecompressedBlockSize int64
+	// MaxDepth is the maximum nesting of values read through ReadArray,
+	// ReadOptional and ReadNested. Unlike the other limits it is only
+	// enforced when reading from a Decoder, which tracks the depth, so
+	// recursive schemas should be read through NewDecoder.
+	MaxDepth int
+}
+
+// DefaultDecoderConfig applies when reading from a Reader that is not a
+// Decoder.
+var DefaultDecoderConfig = DecoderConfig{
+	MaxBytesLength:           64 << 20,
+	MaxItemCount:             16 << 20,
+	MaxBlockSize:             256 << 20,
+	MaxDecompressedBlockSize: 256 << 20,
+	MaxDepth:                 256,
+}
+
+func (config *DecoderConfig) CheckBytesLength(length int64) error {
+	return checkLimit("MaxBytesLength", length, config.MaxBytesLength)
+}
+
+func (config *DecoderConfig) CheckItemCount(count int64) error {
+	return checkLimit("MaxItemCount", count, config.MaxItemCount)
+}
+
+func (config *DecoderConfig) CheckBlockSize(size int64) error {
+	return checkLimit("MaxBlockSize", size, config.MaxBlockSize)
+}
+
+// decompressedBlockSizeExceeded is returned by decompression that stopped
+// after size bytes as the output exceeded limit.
+func decompressedBlockSizeExceeded(size int64, limit int64) error {
+	return &LimitExceededError{
+		Limit: "MaxDecompressedBlockSize",
+		Value: size,
+		Max:   limit,
+	}
+}
+
+// maxInitialCapacity bounds the capacity allocated up front for an item
+// count read from the data, larger collections grow as their items are read.
+const maxInitialCapacity = 1024
+
+func initialCapacity(count int64) int {
+	if count > maxInitialCapacity {
+		return maxInitialCapacity
+	}
+	return int(count)
+}
+
+func checkLimit(limit string, value int64, max int64) error {
+	if value < 0 {
+		return ErrNegativeLength
+	}
+	if max > 0 && value > max {
+		return &LimitExceededError{
+			Limit: limit,
+			Value: value,
+			Max:   max,
+		}
+	}
+	return nil
+}
+
+var _ Reader = (*Decoder)(nil)
+
+// Decoder is a Reader that carries a DecoderConfig to the Read functions and
+// tracks the nesting depth of the value being read.
+type Decoder struct {
+	reader Reader
+	config DecoderConfig
+	depth  int
+}
+
+func NewDecoder(reader Reader, config DecoderConfig) *Decoder {
+	return &Decoder{
+		reader: reader,
+		config: config,
+	}
+}
+
+func (decoder *Decoder) Read(p []byte) (n int, err error) {
+	return decoder.reader.Read(p)
+}
+
+func (decoder *Decoder) ReadByte() (c byte, err error) {
+	return decoder.reader.ReadByte()
+}
+
+func (decoder *Decoder) Config() *DecoderConfig {
+	return &decoder.config
+}
+
+// DecoderConfigOf returns the config of reader if it is a Decoder, otherwise
+// DefaultDecoderConfig.
+func DecoderConfigOf(reader io.Reader) *DecoderConfig {
+	decoder, ok := reader.(*Decoder)
+	if !ok {
+		return &DefaultDecoderConfig
+	}
+	return &decoder.config
+}
+
+// ReadNested calls read one level deeper, returning a LimitExceededError
+// instead if that exceeds the MaxDepth of reader. Depth is only tracked when
+// reader is a Decoder.
+func ReadNested(reader Reader, read func() error) error {
+	decoder, ok := reader.(*Decoder)
+	if !ok {
+		return read()
+	}
+	decoder.depth++
+	defer func() {
+		decoder.depth--
+	}()
+	if decoder.config.MaxDepth > 0 && decoder.depth > decoder.config.MaxDepth {
+		return &LimitExceededError{
+			Limit: "MaxDepth",
+			Value: int64(decoder.depth),
+			Max:   int64(decoder.config.MaxDepth),
+		}
+	}
+	return read()
+}
+
+// readLength reads a length and checks it with check.
+func readLength(reader Reader, check func(int64) error) (length int64, err error) {
+	err = ReadLong(reader, &length)
+	if err != nil {
+		return
+	}
+	err = check(length)
+	return
+}
+
+// ReadCollectionBlockHeader reads the header of the next block of an array or
+// map. A negative count is followed by the size of the block in bytes, which
+// lets readers skip it, otherwise size is -1.
+func ReadCollectionBlockHeader(reader Reader) (count int64, size int64, err error) {
+	err = ReadLong(reader, &count)
+	if err != nil {
+		return
+	}
+	size = -1
+	if count >= 0 {
+		return
+	}
+	count = -count
+	if count < 0 {
+		return 0, 0, ErrNegativeLength
+	}
+	err = ReadLong(reader, &size)
+	if err != nil {
+		return
+	}
+	if size < 0 {
+		return 0, 0, ErrNegativeLength
+	}
+	return
+}
+
+// readItemCount reads the item count of the next block of an array or map,
+// checking the running total against MaxItemCount.
+func readItemCount(reader Reader, total *int64) (count int64, err error) {
+	count, _, err = ReadCollectionBlockHeader(reader)
+	if err != nil {
+		return
+	}
+	*total += count
+	err = DecoderConfigOf(reader).CheckItemCount(*total)
+	return
+}
@@ -0,0 +1,169 @@
+package avro_test
+
+import (
+	"bytes"
+	"errors"
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avro"
+)
+
+func encodeLongs(values ...int64) *bytes.Buffer {
+	var buffer bytes.Buffer
+	for _, value := range values {
+		_, err := avro.WriteLong(&buffer, value)
+		So(err, ShouldBeNil)
+	}
+	return &buffer
+}
+
+func TestDecoder(t *testing.T) {
+	Convey("TestDecoder", t, func() {
+		config := avro.DecoderConfig{
+			MaxBytesLength: 16,
+			MaxItemCount:   4,
+			MaxBlockSize:   64,
+			MaxDepth:       2,
+		}
+		Convey("negative bytes length", func() {
+			_, err := avro.ReadBytes(avro.NewDecoder(encodeLongs(-1), config))
+			So(err, ShouldEqual, avro.ErrNegativeLength)
+		})
+		Convey("bytes length", func() {
+			_, err := avro.ReadString(avro.NewDecoder(encodeLongs(1<<40), config))
+			So(err, ShouldResemble, &avro.LimitExceededError{
+				Limit: "MaxBytesLength",
+				Value: 1 << 40,
+				Max:   16,
+			})
+		})
+		Convey("default config", func() {
+			_, err := avro.ReadBytes(encodeLongs(1 << 40))
+			So(err, ShouldHaveSameTypeAs, &avro.LimitExceededError{})
+		})
+		Convey("item count across blocks", func() {
+			var slice []int64
+			err := avro.ReadLongSlice(avro.NewDecoder(encodeLongs(3, 1, 2, 3, 2, 4, 5, 0), config), &slice)
+			So(err, ShouldResemble, &avro.LimitExceededError{
+				Limit: "MaxItemCount",
+				Value: 5,
+				Max:   4,
+			})
+			err = avro.ReadLongSlice(avro.NewDecoder(encodeLongs(1<<62), config), &slice)
+			So(err, ShouldHaveSameTypeAs, &avro.LimitExceededError{})
+			var buffer bytes.Buffer
+			_, err = avro.WriteLongMap(&buffer, map[string]int64{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5})
+			So(err, ShouldBeNil)
+			var values map[string]int64
+			err = avro.ReadLongMap(avro.NewDecoder(&buffer, config), &values)
+			So(err, ShouldHaveSameTypeAs, &avro.LimitExceededError{})
+		})
+		Convey("declared item count", func() {
+			// a count within MaxItemCount but followed by no items must
+			// not allocate for all of them
+			var before, after runtime.MemStats
+			runtime.ReadMemStats(&before)
+			var values map[string]string
+			err := avro.ReadStringMap(encodeLongs(16<<20), &values)
+			So(err, ShouldNotBeNil)
+			var slice []float64
+			err = avro.ReadDoubleSlice(encodeLongs(16<<20), &slice)
+			So(err, ShouldNotBeNil)
+			runtime.ReadMemStats(&after)
+			So(after.TotalAlloc-before.TotalAlloc, ShouldBeLessThan, 1<<20)
+		})
+		Convey("depth", func() {
+			readNested := func(depth int) error {
+				decoder := avro.NewDecoder(encodeLongs(1, 1, 1, 0, 0, 0), config)
+				var read func(level int) avro.ReadItemFunc
+				read = func(level int) avro.ReadItemFunc {
+					return func(int) error {
+						if level == depth {
+							var value int64
+							return avro.ReadLong(decoder, &value)
+						}
+						return avro.ReadArray(decoder, read(level+1))
+					}
+				}
+				return avro.ReadArray(decoder, read(1))
+			}
+			So(readNested(2), ShouldBeNil)
+			So(readNested(3), ShouldResemble, &avro.LimitExceededError{
+				Limit: "MaxDepth",
+				Value: 3,
+				Max:   2,
+			})
+		})
+		Convey("block size", func() {
+			var buffer bytes.Buffer
+			fileWriter, err := avro.NewFileWriter(avro.NewFileWriterInput{
+				Writer: &buffer,
+				Schema: parsePersonSchema(),
+			})
+			So(err, ShouldBeNil)
+			people := generatePeople(10)
+			for i := range people {
+				err = fileWriter.Append(&people[i])
+				So(err, ShouldBeNil)
+			}
+			err = fileWriter.Close()
+			So(err, ShouldBeNil)
+			fileReader, err := avro.NewFileReader(avro.NewFileReaderInput{
+				Reader: &buffer,
+				DecoderConfig: &avro.DecoderConfig{
+					MaxBlockSize: 64,
+				},
+			})
+			So(err, ShouldBeNil)
+			var person Person
+			So(fileReader.Next(&person), ShouldBeFalse)
+			So(fileReader.Err(), ShouldHaveSameTypeAs, &avro.LimitExceededError{})
+		})
+		Convey("decompressed block size", func() {
+			for _, codec := range []avro.CompressionCodec{
+				avro.CompressionCodecNull,
+				avro.CompressionCodecDeflate,
+				avro.CompressionCodecSnappy,
+				avro.CompressionCodecZstandard,
+				avro.CompressionCodecXZ,
+			} {
+				// 16 MiB of zeros compresses to a few KiB
+				var buffer bytes.Buffer
+				header, err := avro.NewObjectContainerHeader(avro.NewObjectContainerHeaderInput{
+					Schema:           parsePersonSchema(),
+					CompressionCodec: codec,
+				})
+				So(err, ShouldBeNil)
+				err = header.WriteAvro(&buffer)
+				So(err, ShouldBeNil)
+				var block avro.ObjectBlock
+				codecWriter, err := avro.NewCodecWriter(&block, codec)
+				So(err, ShouldBeNil)
+				_, err = codecWriter.Write(make([]byte, 16<<20))
+				So(err, ShouldBeNil)
+				err = codecWriter.Close()
+				So(err, ShouldBeNil)
+				block.Length = 1
+				_, err = avro.WriteObjectBlock(&buffer, &block, header.Sync)
+				So(err, ShouldBeNil)
+
+				fileReader, err := avro.NewFileReader(avro.NewFileReaderInput{
+					Reader: &buffer,
+					DecoderConfig: &avro.DecoderConfig{
+						MaxDecompressedBlockSize: 1 << 20,
+					},
+				})
+				So(err, ShouldBeNil)
+				var person Person
+				So(fileReader.Next(&person), ShouldBeFalse)
+				var limitErr *avro.LimitExceededError
+				So(errors.As(fileReader.Err(), &limitErr), ShouldBeTrue)
+				So(limitErr.Limit, ShouldEqual, "MaxDecompressedBlockSize")
+				So(limitErr.Max, ShouldEqual, 1<<20)
+			}
+		})
+	})
+}
@@ -0,0 +1,129 @@
+package avro
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+)
+
+const defaultDeflateLevel = flate.DefaultCompression
+
+// deflateCodec compresses blocks as raw deflate (RFC 1951) with no zlib
+// header or checksum, as required by the specification.
+type deflateCodec struct {
+	level   int
+	writers *sync.Pool
+}
+
+// NewDeflateCodec returns a deflate codec compressing at level, which takes
+// the same values as compress/flate.
+func NewDeflateCodec(level int) (Codec, error) {
+	if level < flate.HuffmanOnly || level > flate.BestCompression {
+		return nil, fmt.Errorf("invalid deflate compression level %d", level)
+	}
+	return newDeflateCodec(level), nil
+}
+
+func newDeflateCodec(level int) *deflateCodec {
+	return &deflateCodec{
+		level: level,
+		writers: &sync.Pool{
+			New: func() interface{} {
+				writer, err := flate.NewWriter(ioutil.Discard, level)
+				if err != nil {
+					panic(err)
+				}
+				return writer
+			},
+		},
+	}
+}
+
+func (codec *deflateCodec) Name() CompressionCodec {
+	return CompressionCodecDeflate
+}
+
+func (codec *deflateCodec) WithLevel(level int) (Codec, error) {
+	return NewDeflateCodec(level)
+}
+
+func (codec *deflateCodec) Compress(dst, src []byte) ([]byte, error) {
+	buffer := bytes.NewBuffer(dst)
+	writer := codec.writers.Get().(*flate.Writer)
+	defer codec.writers.Put(writer)
+	writer.Reset(buffer)
+	_, err := writer.Write(src)
+	if err != nil {
+		return nil, err
+	}
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (codec *deflateCodec) Decompress(dst, src []byte) ([]byte, error) {
+	return codec.decompressLimit(dst, src, 0)
+}
+
+func (codec *deflateCodec) decompressLimit(dst, src []byte, limit int64) ([]byte, error) {
+	return readAllInto(dst, flate.NewReader(bytes.NewReader(src)), limit)
+}
+
+// DecompressLegacy also accepts the zlib wrapped blocks written by earlier
+// versions of this package. A raw deflate block is never mistaken for a zlib
+// stream as its first byte would have to describe a stored block with non-zero
+// padding bits.
+func (codec *deflateCodec) DecompressLegacy(dst, src []byte) ([]byte, error) {
+	return codec.decompressLegacyLimit(dst, src, 0)
+}
+
+func (codec *deflateCodec) decompressLegacyLimit(dst, src []byte, limit int64) ([]byte, error) {
+	if len(src) < 2 || !isZlibHeader(src) {
+		return codec.decompressLimit(dst, src, limit)
+	}
+	reader, err := zlib.NewReader(bytes.NewReader(src))
+	if err != nil {
+		return nil, err
+	}
+	return readAllInto(dst, reader, limit)
+}
+
+func isZlibHeader(header []byte) bool {
+	cmf, flg := header[0], header[1]
+	if cmf&0x0f != 8 || cmf>>4 > 7 {
+		return false
+	}
+	if flg&0x20 != 0 {
+		return false
+	}
+	return (uint16(cmf)<<8|uint16(flg))%31 == 0
+}
+
+// readAllInto appends everything read from reader to dst, stopping with a
+// LimitExceededError once more than limit bytes have been read. Zero means no
+// limit.
+func readAllInto(dst []byte, reader io.ReadCloser, limit int64) ([]byte, error) {
+	buffer := bytes.NewBuffer(dst)
+	var source io.Reader = reader
+	if limit > 0 {
+		source = io.LimitReader(reader, limit+1)
+	}
+	n, err := io.Copy(buffer, source)
+	if err != nil {
+		return nil, err
+	}
+	err = reader.Close()
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && n > limit {
+		return nil, decompressedBlockSizeExceeded(n, limit)
+	}
+	return buffer.Bytes(), nil
+}
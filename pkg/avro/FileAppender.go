@@ -0,0 +1,105 @@
+package avro
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avroschema"
+)
+
+var ErrSchemaMismatch = errors.New("schema does not match file schema")
+
+type NewFileAppenderInput struct {
+	File io.ReadWriteSeeker
+	// Schema must match the avro.schema of File.
+	Schema avroschema.Schema
+	// MaxBlockLength, MaxBlockSize, CompressionWorkers and MaxPendingBlocks
+	// are as for NewFileWriterInput.
+	MaxBlockLength     int64
+	MaxBlockSize       int
+	CompressionWorkers int
+	MaxPendingBlocks   int
+	// Index is as for NewFileWriterInput, the index covers the existing
+	// blocks as well as those appended.
+	Index io.Writer
+}
+
+// NewFileAppender returns a FileWriter that appends blocks to an existing
+// object container file, reusing the codec and sync marker from its header.
+// Every block in the file is read to check that the file ends with a complete
+// block, new blocks are written from the end of the last one.
+func NewFileAppender(input NewFileAppenderInput) (fileWriter *FileWriter, err error) {
+	_, err = input.File.Seek(0, io.SeekStart)
+	if err != nil {
+		return
+	}
+	reader := &countingReader{
+		reader: bufio.NewReader(input.File),
+	}
+	header := new(ObjectContainerHeader)
+	err = header.ReadAvro(reader)
+	if err != nil {
+		return
+	}
+	err = checkSchemaMatches(header, input.Schema)
+	if err != nil {
+		return
+	}
+	codec, err := header.Codec()
+	if err != nil {
+		return
+	}
+	err = checkCompresses(codec)
+	if err != nil {
+		return
+	}
+	end := reader.offset
+	blockIterator := NewObjectBlockIterator(NewObjectBlockIteratorInput{
+		Reader:       reader,
+		ExpectedSync: header.Sync,
+	})
+	index := &BlockIndex{
+		Sync: header.Sync,
+	}
+	var block ObjectBlock
+	for blockIterator.Next(&block) {
+		index.add(end, block.Length)
+		end = reader.offset
+	}
+	err = blockIterator.Err()
+	if err != nil {
+		err = fmt.Errorf("incomplete block at offset %d: %v", end, err)
+		return
+	}
+	_, err = input.File.Seek(end, io.SeekStart)
+	if err != nil {
+		return
+	}
+	config := fileWriterConfig{
+		maxBlockLength:     input.MaxBlockLength,
+		maxBlockSize:       input.MaxBlockSize,
+		compressionWorkers: input.CompressionWorkers,
+		maxPendingBlocks:   input.MaxPendingBlocks,
+		offset:             end,
+		indexWriter:        input.Index,
+	}
+	if input.Index != nil {
+		config.index = index
+	}
+	fileWriter = newFileWriter(bufio.NewWriter(input.File), header, codec, config)
+	return
+}
+
+func checkSchemaMatches(header *ObjectContainerHeader, schema avroschema.Schema) (err error) {
+	headerSchema, err := header.Schema()
+	if err != nil {
+		return
+	}
+	if !bytes.Equal(avroschema.CanonicalForm(headerSchema), avroschema.CanonicalForm(schema)) {
+		return ErrSchemaMismatch
+	}
+	return
+}
@@ -0,0 +1,123 @@
+package avro_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avro"
+	"github.com/Ryan-A-B/avro-go/pkg/avroschema"
+)
+
+func TestFileAppender(t *testing.T) {
+	Convey("TestFileAppender", t, func() {
+		file, err := ioutil.TempFile("", "people-*.avro")
+		So(err, ShouldBeNil)
+		defer os.Remove(file.Name())
+		defer file.Close()
+		people := generatePeople(100)
+		fileWriter, err := avro.NewFileWriter(avro.NewFileWriterInput{
+			Writer:           file,
+			Schema:           parsePersonSchema(),
+			CompressionCodec: avro.CompressionCodecDeflate,
+			MaxBlockLength:   30,
+		})
+		So(err, ShouldBeNil)
+		for i := range people[:50] {
+			err = fileWriter.Append(&people[i])
+			So(err, ShouldBeNil)
+		}
+		err = fileWriter.Close()
+		So(err, ShouldBeNil)
+		Convey("appends blocks", func() {
+			var indexBuffer bytes.Buffer
+			fileAppender, err := avro.NewFileAppender(avro.NewFileAppenderInput{
+				File:           file,
+				Schema:         parsePersonSchema(),
+				MaxBlockLength: 30,
+				Index:          &indexBuffer,
+			})
+			So(err, ShouldBeNil)
+			So(fileAppender.Header().Sync, ShouldEqual, fileWriter.Header().Sync)
+			for i := range people[50:] {
+				err = fileAppender.Append(&people[50+i])
+				So(err, ShouldBeNil)
+			}
+			err = fileAppender.Close()
+			So(err, ShouldBeNil)
+			_, err = file.Seek(0, io.SeekStart)
+			So(err, ShouldBeNil)
+			fileReader, err := avro.NewFileReader(avro.NewFileReaderInput{
+				Reader: file,
+			})
+			So(err, ShouldBeNil)
+			So(fileReader.CompressionCodec(), ShouldEqual, avro.CompressionCodecDeflate)
+			var actual []Person
+			var person Person
+			for fileReader.Next(&person) {
+				actual = append(actual, person)
+			}
+			So(fileReader.Err(), ShouldBeNil)
+			So(actual, ShouldResemble, people)
+			var index avro.BlockIndex
+			err = index.ReadAvro(&indexBuffer)
+			So(err, ShouldBeNil)
+			_, err = file.Seek(0, io.SeekStart)
+			So(err, ShouldBeNil)
+			built, err := avro.BuildBlockIndex(file)
+			So(err, ShouldBeNil)
+			So(&index, ShouldResemble, built)
+			So(index.Count(), ShouldEqual, len(people))
+		})
+		Convey("schema mismatch", func() {
+			schema, err := avroschema.ParseSchema([]byte(`{
+				"type": "record",
+				"name": "Person",
+				"fields": [
+					{"name": "name", "type": "string"}
+				]
+			}`))
+			So(err, ShouldBeNil)
+			_, err = avro.NewFileAppender(avro.NewFileAppenderInput{
+				File:   file,
+				Schema: schema,
+			})
+			So(err, ShouldEqual, avro.ErrSchemaMismatch)
+		})
+		Convey("equivalent schema", func() {
+			schema, err := avroschema.ParseSchema([]byte(`{
+				"name": "Person",
+				"doc": "written by another tool",
+				"type": "record",
+				"fields": [
+					{"name": "name", "type": {"type": "string"}},
+					{"name": "age", "type": "int", "default": 0}
+				]
+			}`))
+			So(err, ShouldBeNil)
+			fileAppender, err := avro.NewFileAppender(avro.NewFileAppenderInput{
+				File:   file,
+				Schema: schema,
+			})
+			So(err, ShouldBeNil)
+			err = fileAppender.Close()
+			So(err, ShouldBeNil)
+		})
+		Convey("incomplete block", func() {
+			info, err := file.Stat()
+			So(err, ShouldBeNil)
+			err = file.Truncate(info.Size() - 4)
+			So(err, ShouldBeNil)
+			_, err = avro.NewFileAppender(avro.NewFileAppenderInput{
+				File:   file,
+				Schema: parsePersonSchema(),
+			})
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldStartWith, "incomplete block at offset")
+		})
+	})
+}
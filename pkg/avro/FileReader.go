@@ -0,0 +1,242 @@
+package avro
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avroschema"
+)
+
+// Should be called Reader but already have a Reader for the reader union
+type Unmarshaler interface {
+	ReadAvro(r Reader) error
+}
+
+// FileReader reads the records of an object container file, decompressing
+// each block as it is reached.
+type FileReader struct {
+	source        io.Reader
+	buffered      *bufio.Reader
+	index         *BlockIndex
+	header        ObjectContainerHeader
+	schema        avroschema.Schema
+	codec         Codec
+	decompress    decompressFunc
+	blockIterator *ObjectBlockIterator
+	block         ObjectBlock
+	decompressed  []byte
+	records       bytes.Reader
+	config        DecoderConfig
+	decoder       *Decoder
+	remaining     int64
+	skip          int64
+	recover       func(corruption Corruption)
+	err           error
+}
+
+type NewFileReaderInput struct {
+	Reader io.Reader
+	// Legacy accepts blocks written by earlier versions of this package, see
+	// NewLegacyCodecReader.
+	Legacy bool
+	// Recover enables resynchronisation, see NewObjectBlockIteratorInput.
+	// Blocks that fail to decompress or decode are also skipped and reported.
+	Recover func(corruption Corruption)
+	// Index enables SeekToRecord, see BuildBlockIndex.
+	Index *BlockIndex
+	// DecoderConfig limits the header, blocks and records read, nil means
+	// DefaultDecoderConfig.
+	DecoderConfig *DecoderConfig
+}
+
+// NewFileReader reads and parses the object container header from
+// input.Reader.
+func NewFileReader(input NewFileReaderInput) (fileReader *FileReader, err error) {
+	fileReader = &FileReader{
+		source:  input.Reader,
+		index:   input.Index,
+		recover: input.Recover,
+		config:  DefaultDecoderConfig,
+	}
+	if input.DecoderConfig != nil {
+		fileReader.config = *input.DecoderConfig
+	}
+	fileReader.decoder = NewDecoder(&fileReader.records, fileReader.config)
+	reader, ok := input.Reader.(Reader)
+	if !ok {
+		fileReader.buffered = bufio.NewReader(input.Reader)
+		reader = fileReader.buffered
+	}
+	headerReader := &countingReader{
+		reader: reader,
+	}
+	err = fileReader.header.ReadAvro(NewDecoder(headerReader, fileReader.config))
+	if err != nil {
+		return nil, err
+	}
+	fileReader.schema, err = fileReader.header.Schema()
+	if err != nil {
+		return nil, err
+	}
+	fileReader.codec, err = fileReader.header.Codec()
+	if err != nil {
+		return nil, err
+	}
+	fileReader.decompress = selectDecompressFunc(fileReader.codec, input.Legacy)
+	if input.Index != nil && input.Index.Sync != fileReader.header.Sync {
+		return nil, ErrIndexMismatch
+	}
+	fileReader.blockIterator = NewObjectBlockIterator(NewObjectBlockIteratorInput{
+		Reader:       NewDecoder(reader, fileReader.config),
+		ExpectedSync: fileReader.header.Sync,
+		Offset:       headerReader.offset,
+		Recover:      input.Recover,
+	})
+	return
+}
+
+func (fileReader *FileReader) Header() *ObjectContainerHeader {
+	return &fileReader.header
+}
+
+// Schema returns the writer schema parsed from the header's avro.schema.
+func (fileReader *FileReader) Schema() avroschema.Schema {
+	return fileReader.schema
+}
+
+func (fileReader *FileReader) Codec() Codec {
+	return fileReader.codec
+}
+
+func (fileReader *FileReader) CompressionCodec() CompressionCodec {
+	return fileReader.codec.Name()
+}
+
+// Next decodes the next record into value, returning false when there are no
+// more records or an error occurred.
+func (fileReader *FileReader) Next(value Unmarshaler) bool {
+	if fileReader.err != nil {
+		return false
+	}
+	for {
+		for fileReader.remaining == 0 {
+			if !fileReader.blockIterator.Next(&fileReader.block) {
+				fileReader.err = fileReader.blockIterator.Err()
+				return false
+			}
+			err := fileReader.decompressBlock()
+			if err != nil {
+				if fileReader.recover == nil {
+					fileReader.err = err
+					return false
+				}
+				fileReader.skipBlock(err)
+			}
+		}
+		err := value.ReadAvro(fileReader.decoder)
+		if err == nil {
+			fileReader.remaining--
+			if fileReader.skip > 0 {
+				fileReader.skip--
+				continue
+			}
+			return true
+		}
+		if fileReader.recover == nil {
+			fileReader.err = err
+			return false
+		}
+		fileReader.skipBlock(err)
+	}
+}
+
+// skipBlock reports the current block as corrupt and discards its remaining
+// records.
+func (fileReader *FileReader) skipBlock(cause error) {
+	fileReader.recover(Corruption{
+		Start: fileReader.blockIterator.BlockOffset(),
+		End:   fileReader.blockIterator.Offset(),
+		Err:   cause,
+	})
+	fileReader.remaining = 0
+	fileReader.skip = 0
+}
+
+// SeekToRecord positions the FileReader so the next call to Next decodes
+// record n, counting from zero. It requires NewFileReaderInput.Index and a
+// Reader that implements io.Seeker. The block containing the record is read
+// directly, the records before it within the block are decoded by Next and
+// discarded.
+func (fileReader *FileReader) SeekToRecord(n int64) (err error) {
+	if fileReader.index == nil {
+		return errors.New("file reader has no block index")
+	}
+	seeker, ok := fileReader.source.(io.Seeker)
+	if !ok {
+		return errors.New("file reader is not seekable")
+	}
+	i, err := fileReader.index.Search(n)
+	if err != nil {
+		return
+	}
+	entry := fileReader.index.Entries[i]
+	var first int64
+	if i > 0 {
+		first = fileReader.index.Entries[i-1].Count
+	}
+	_, err = seeker.Seek(entry.Offset, io.SeekStart)
+	if err != nil {
+		return
+	}
+	var reader Reader
+	if fileReader.buffered != nil {
+		fileReader.buffered.Reset(fileReader.source)
+		reader = fileReader.buffered
+	} else {
+		reader = fileReader.source.(Reader)
+	}
+	fileReader.blockIterator = NewObjectBlockIterator(NewObjectBlockIteratorInput{
+		Reader:       NewDecoder(reader, fileReader.config),
+		ExpectedSync: fileReader.header.Sync,
+		Offset:       entry.Offset,
+		Recover:      fileReader.recover,
+	})
+	fileReader.remaining = 0
+	fileReader.skip = 0
+	fileReader.err = nil
+	if !fileReader.blockIterator.Next(&fileReader.block) {
+		err = fileReader.blockIterator.Err()
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+		fileReader.err = err
+		return
+	}
+	if fileReader.blockIterator.BlockOffset() != entry.Offset || fileReader.block.Length != entry.Count-first {
+		fileReader.err = ErrIndexMismatch
+		return fileReader.err
+	}
+	err = fileReader.decompressBlock()
+	if err != nil {
+		fileReader.err = err
+		return
+	}
+	fileReader.skip = n - first
+	return
+}
+
+func (fileReader *FileReader) Err() error {
+	return fileReader.err
+}
+
+func (fileReader *FileReader) decompressBlock() (err error) {
+	fileReader.decompressed, err = fileReader.decompress(fileReader.decompressed[:0], fileReader.block.Bytes(), fileReader.config.MaxDecompressedBlockSize)
+	if err != nil {
+		return
+	}
+	fileReader.records.Reset(fileReader.decompressed)
+	fileReader.remaining = fileReader.block.Length
+	return
+}
@@ -0,0 +1,158 @@
+package avro_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avro"
+	"github.com/Ryan-A-B/avro-go/pkg/avroschema"
+)
+
+func TestFileReader(t *testing.T) {
+	Convey("TestFileReader", t, func() {
+		Convey("testdata", func() {
+			var name string
+			var codec avro.CompressionCodec
+			var legacy bool
+			Convey("null", func() {
+				name = "testdata/people-null.avro"
+				codec = avro.CompressionCodecNull
+			})
+			Convey("deflate", func() {
+				name = "testdata/people-deflate.avro"
+				codec = avro.CompressionCodecDeflate
+			})
+			Convey("deflate legacy", func() {
+				name = "testdata/people-deflate.avro"
+				codec = avro.CompressionCodecDeflate
+				legacy = true
+			})
+			Convey("deflate zlib legacy", func() {
+				name = "testdata/people-deflate-zlib.avro"
+				codec = avro.CompressionCodecDeflate
+				legacy = true
+			})
+			Convey("snappy", func() {
+				name = "testdata/people-snappy.avro"
+				codec = avro.CompressionCodecSnappy
+			})
+			Convey("snappy framed legacy", func() {
+				name = "testdata/people-snappy-framed.avro"
+				codec = avro.CompressionCodecSnappy
+				legacy = true
+			})
+			Convey("zstandard", func() {
+				name = "testdata/people-zstandard.avro"
+				codec = avro.CompressionCodecZstandard
+			})
+			Convey("bzip2", func() {
+				name = "testdata/people-bzip2.avro"
+				codec = avro.CompressionCodecBzip2
+			})
+			Convey("xz", func() {
+				name = "testdata/people-xz.avro"
+				codec = avro.CompressionCodecXZ
+			})
+			file, err := os.Open(name)
+			So(err, ShouldBeNil)
+			defer file.Close()
+			fileReader, err := avro.NewFileReader(avro.NewFileReaderInput{
+				Reader: file,
+				Legacy: legacy,
+			})
+			So(err, ShouldBeNil)
+			So(fileReader.CompressionCodec(), ShouldEqual, codec)
+			So(fileReader.Schema().GetType(), ShouldEqual, avroschema.AvroTypeRecord)
+			So(fileReader.Schema().(*avroschema.Record).Name, ShouldEqual, "Person")
+			count := 0
+			var person Person
+			for fileReader.Next(&person) {
+				So(person.Name, ShouldHaveLength, 64)
+				count++
+			}
+			So(fileReader.Err(), ShouldBeNil)
+			So(count, ShouldEqual, 16*1024)
+		})
+		Convey("zlib without legacy", func() {
+			file, err := os.Open("testdata/people-deflate-zlib.avro")
+			So(err, ShouldBeNil)
+			defer file.Close()
+			fileReader, err := avro.NewFileReader(avro.NewFileReaderInput{
+				Reader: file,
+			})
+			So(err, ShouldBeNil)
+			var person Person
+			So(fileReader.Next(&person), ShouldBeFalse)
+			So(fileReader.Err(), ShouldNotBeNil)
+		})
+		Convey("recover", func() {
+			file := writeTestFile(avro.CompressionCodecSnappy, 10, 10)
+			file.data[file.blockOffsets[5]+10]++
+			var corruptions []avro.Corruption
+			fileReader, err := avro.NewFileReader(avro.NewFileReaderInput{
+				Reader: bytes.NewReader(file.data),
+				Recover: func(corruption avro.Corruption) {
+					corruptions = append(corruptions, corruption)
+				},
+			})
+			So(err, ShouldBeNil)
+			count := 0
+			var person Person
+			for fileReader.Next(&person) {
+				count++
+			}
+			So(fileReader.Err(), ShouldBeNil)
+			So(count, ShouldEqual, 90)
+			So(corruptions, ShouldHaveLength, 1)
+			So(corruptions[0].Start, ShouldEqual, file.blockOffsets[5])
+			So(corruptions[0].End, ShouldEqual, file.blockOffsets[6])
+		})
+		Convey("round trip", func() {
+			var codec avro.CompressionCodec
+			Convey("null", func() {
+				codec = avro.CompressionCodecNull
+			})
+			Convey("deflate", func() {
+				codec = avro.CompressionCodecDeflate
+			})
+			Convey("snappy", func() {
+				codec = avro.CompressionCodecSnappy
+			})
+			Convey("zstandard", func() {
+				codec = avro.CompressionCodecZstandard
+			})
+			Convey("xz", func() {
+				codec = avro.CompressionCodecXZ
+			})
+			people := generatePeople(1000)
+			var buffer bytes.Buffer
+			fileWriter, err := avro.NewFileWriter(avro.NewFileWriterInput{
+				Writer:           &buffer,
+				Schema:           parsePersonSchema(),
+				CompressionCodec: codec,
+				MaxBlockLength:   64,
+			})
+			So(err, ShouldBeNil)
+			for i := range people {
+				err = fileWriter.Append(&people[i])
+				So(err, ShouldBeNil)
+			}
+			err = fileWriter.Close()
+			So(err, ShouldBeNil)
+			fileReader, err := avro.NewFileReader(avro.NewFileReaderInput{
+				Reader: &buffer,
+			})
+			So(err, ShouldBeNil)
+			var actual []Person
+			var person Person
+			for fileReader.Next(&person) {
+				actual = append(actual, person)
+			}
+			So(fileReader.Err(), ShouldBeNil)
+			So(actual, ShouldResemble, people)
+		})
+	})
+}
@@ -0,0 +1,269 @@
+package avro
+
+import (
+	"bufio"
+	"errors"
+	"io"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avroschema"
+)
+
+// DefaultMaxBlockSize is the uncompressed size in bytes at which a FileWriter
+// cuts a block when NewFileWriterInput.MaxBlockSize is not set.
+const DefaultMaxBlockSize = 64 * 1024
+
+var ErrFileWriterClosed = errors.New("file writer closed")
+
+// FileWriter writes an object container file. Records are buffered into
+// blocks which are compressed and written, followed by the header's sync
+// marker, once they reach MaxBlockLength records or MaxBlockSize bytes.
+type FileWriter struct {
+	writer         *bufio.Writer
+	header         *ObjectContainerHeader
+	codec          Codec
+	maxBlockLength int64
+	maxBlockSize   int
+	block          ObjectBlock
+	compressed     []byte
+	pipeline       *compressionPipeline
+	offset         int64
+	index          *BlockIndex
+	indexWriter    io.Writer
+	closed         bool
+	err            error
+}
+
+type NewFileWriterInput struct {
+	Writer           io.Writer
+	Schema           avroschema.Schema
+	CompressionCodec CompressionCodec
+	// Codec takes precedence over CompressionCodec, allowing codecs to be
+	// configured, e.g. with LevelCodec.WithLevel.
+	Codec Codec
+	// Sync is generated with GenerateSync when left as the zero value.
+	Sync [16]byte
+	// MaxBlockLength is the maximum number of records per block, zero means no limit.
+	MaxBlockLength int64
+	// MaxBlockSize is the uncompressed size in bytes at which a block is
+	// written, zero means DefaultMaxBlockSize.
+	MaxBlockSize int
+	// CompressionWorkers is the number of goroutines compressing blocks, zero
+	// means blocks are compressed by the goroutine calling Append.
+	CompressionWorkers int
+	// MaxPendingBlocks bounds the number of blocks waiting to be compressed or
+	// written before Append blocks, zero means twice CompressionWorkers.
+	MaxPendingBlocks int
+	// Meta is user metadata to include in the header. Keys beginning with
+	// avro. are reserved and return ErrReservedMetaKey.
+	Meta map[string][]byte
+	// Index, when set, receives the BlockIndex of the file when the
+	// FileWriter is closed.
+	Index io.Writer
+}
+
+// NewFileWriter writes the object container header to input.Writer and
+// returns a FileWriter ready to Append records.
+func NewFileWriter(input NewFileWriterInput) (fileWriter *FileWriter, err error) {
+	codec := input.Codec
+	if codec == nil {
+		name := input.CompressionCodec
+		if name == "" {
+			name = CompressionCodecNull
+		}
+		codec, err = LookupCodec(name)
+		if err != nil {
+			return
+		}
+	}
+	err = checkCompresses(codec)
+	if err != nil {
+		return
+	}
+	sync := input.Sync
+	if sync == [16]byte{} {
+		sync = GenerateSync()
+	}
+	header, err := NewObjectContainerHeader(NewObjectContainerHeaderInput{
+		Schema:           input.Schema,
+		CompressionCodec: codec.Name(),
+		Sync:             sync,
+		Meta:             input.Meta,
+	})
+	if err != nil {
+		return
+	}
+	writer := bufio.NewWriter(input.Writer)
+	headerWriter := &countingWriter{
+		writer: writer,
+	}
+	err = header.WriteAvro(headerWriter)
+	if err != nil {
+		return
+	}
+	config := fileWriterConfig{
+		maxBlockLength:     input.MaxBlockLength,
+		maxBlockSize:       input.MaxBlockSize,
+		compressionWorkers: input.CompressionWorkers,
+		maxPendingBlocks:   input.MaxPendingBlocks,
+		offset:             headerWriter.offset,
+		indexWriter:        input.Index,
+	}
+	if input.Index != nil {
+		config.index = &BlockIndex{
+			Sync: sync,
+		}
+	}
+	fileWriter = newFileWriter(writer, header, codec, config)
+	return
+}
+
+// fileWriterConfig holds the options shared by NewFileWriterInput and
+// NewFileAppenderInput.
+type fileWriterConfig struct {
+	maxBlockLength     int64
+	maxBlockSize       int
+	compressionWorkers int
+	maxPendingBlocks   int
+	// offset is the position in the file at which blocks are written.
+	offset      int64
+	index       *BlockIndex
+	indexWriter io.Writer
+}
+
+func newFileWriter(writer *bufio.Writer, header *ObjectContainerHeader, codec Codec, config fileWriterConfig) *FileWriter {
+	if config.maxBlockSize <= 0 {
+		config.maxBlockSize = DefaultMaxBlockSize
+	}
+	fileWriter := &FileWriter{
+		writer:         writer,
+		header:         header,
+		codec:          codec,
+		maxBlockLength: config.maxBlockLength,
+		maxBlockSize:   config.maxBlockSize,
+		offset:         config.offset,
+		index:          config.index,
+		indexWriter:    config.indexWriter,
+	}
+	if config.compressionWorkers > 0 {
+		fileWriter.pipeline = newCompressionPipeline(writer, codec, header.Sync, config.compressionWorkers, config.maxPendingBlocks, fileWriter.blockWritten)
+	}
+	return fileWriter
+}
+
+func (fileWriter *FileWriter) Header() *ObjectContainerHeader {
+	return fileWriter.header
+}
+
+// Append encodes value into the current block, writing the block out if it
+// has reached its length or size limit. A value that fails to encode is
+// discarded without affecting previously appended records.
+func (fileWriter *FileWriter) Append(value Marshaler) (err error) {
+	if fileWriter.closed {
+		return ErrFileWriterClosed
+	}
+	if fileWriter.err != nil {
+		return fileWriter.err
+	}
+	size := fileWriter.block.Size()
+	_, err = value.WriteAvro(&fileWriter.block)
+	if err != nil {
+		fileWriter.block.data.Truncate(size)
+		return
+	}
+	fileWriter.block.Length++
+	if fileWriter.maxBlockLength > 0 && fileWriter.block.Length >= fileWriter.maxBlockLength {
+		return fileWriter.writeBlock()
+	}
+	if fileWriter.block.Size() >= fileWriter.maxBlockSize {
+		return fileWriter.writeBlock()
+	}
+	return
+}
+
+// Flush writes the current block, if it contains any records, and flushes
+// buffered data to the underlying writer.
+func (fileWriter *FileWriter) Flush() (err error) {
+	if fileWriter.closed {
+		return ErrFileWriterClosed
+	}
+	err = fileWriter.writeBlock()
+	if err != nil {
+		return
+	}
+	if fileWriter.pipeline != nil {
+		err = fileWriter.pipeline.flush()
+	} else {
+		err = fileWriter.writer.Flush()
+	}
+	if err != nil {
+		fileWriter.err = err
+		return
+	}
+	return
+}
+
+// Close flushes the FileWriter and stops any compression goroutines. It does
+// not close the underlying writer.
+func (fileWriter *FileWriter) Close() (err error) {
+	if fileWriter.closed {
+		return ErrFileWriterClosed
+	}
+	err = fileWriter.Flush()
+	fileWriter.closed = true
+	if fileWriter.pipeline != nil {
+		closeErr := fileWriter.pipeline.close()
+		if err == nil {
+			err = closeErr
+		}
+	}
+	if err == nil && fileWriter.indexWriter != nil {
+		err = fileWriter.writeIndex()
+	}
+	return
+}
+
+func (fileWriter *FileWriter) writeIndex() (err error) {
+	writer := bufio.NewWriter(fileWriter.indexWriter)
+	_, err = fileWriter.index.WriteAvro(writer)
+	if err != nil {
+		return
+	}
+	return writer.Flush()
+}
+
+// blockWritten advances the offset past a block of length records and
+// nWritten bytes, adding it to the index if there is one.
+func (fileWriter *FileWriter) blockWritten(length int64, nWritten int) {
+	if fileWriter.index != nil {
+		fileWriter.index.add(fileWriter.offset, length)
+	}
+	fileWriter.offset += int64(nWritten)
+}
+
+func (fileWriter *FileWriter) writeBlock() (err error) {
+	if fileWriter.err != nil {
+		return fileWriter.err
+	}
+	if fileWriter.block.Length == 0 {
+		return
+	}
+	defer func() {
+		fileWriter.err = err
+	}()
+	if fileWriter.pipeline != nil {
+		err = fileWriter.pipeline.write(fileWriter.block.Length, fileWriter.block.Bytes())
+		fileWriter.block.Reset()
+		return
+	}
+	fileWriter.compressed, err = fileWriter.codec.Compress(fileWriter.compressed[:0], fileWriter.block.Bytes())
+	if err != nil {
+		return
+	}
+	n, err := writeObjectBlockData(fileWriter.writer, fileWriter.block.Length, fileWriter.compressed, fileWriter.header.Sync)
+	if err != nil {
+		return
+	}
+	fileWriter.blockWritten(fileWriter.block.Length, n)
+	fileWriter.block.Reset()
+	return
+}
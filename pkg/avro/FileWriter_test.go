@@ -0,0 +1,163 @@
+package avro_test
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avro"
+	"github.com/Ryan-A-B/avro-go/pkg/avroschema"
+)
+
+func parsePersonSchema() avroschema.Schema {
+	schema, err := avroschema.ParseSchema([]byte(`{
+		"type": "record",
+		"name": "Person",
+		"fields": [
+			{"name": "name", "type": "string"},
+			{"name": "age", "type": "int"}
+		]
+	}`))
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}
+
+func generatePeople(n int) []Person {
+	people := make([]Person, n)
+	for i := range people {
+		people[i] = Person{
+			Name: generateRandomString(16),
+			Age:  int32(i),
+		}
+	}
+	return people
+}
+
+func TestFileWriter(t *testing.T) {
+	Convey("TestFileWriter", t, func() {
+		var buffer bytes.Buffer
+		people := generatePeople(100)
+		Convey("cuts blocks by length", func() {
+			fileWriter, err := avro.NewFileWriter(avro.NewFileWriterInput{
+				Writer:         &buffer,
+				Schema:         parsePersonSchema(),
+				MaxBlockLength: 30,
+			})
+			So(err, ShouldBeNil)
+			for i := range people {
+				err = fileWriter.Append(&people[i])
+				So(err, ShouldBeNil)
+			}
+			err = fileWriter.Close()
+			So(err, ShouldBeNil)
+			var header avro.ObjectContainerHeader
+			err = header.ReadAvro(&buffer)
+			So(err, ShouldBeNil)
+			So(header.Sync, ShouldEqual, fileWriter.Header().Sync)
+			So(string(header.Meta["avro.codec"]), ShouldEqual, "null")
+			blockIterator := avro.NewObjectBlockIterator(avro.NewObjectBlockIteratorInput{
+				Reader:       &buffer,
+				ExpectedSync: header.Sync,
+			})
+			var lengths []int64
+			var actual []Person
+			var block avro.ObjectBlock
+			for blockIterator.Next(&block) {
+				lengths = append(lengths, block.Length)
+				for i := int64(0); i < block.Length; i++ {
+					var person Person
+					err = person.ReadAvro(&block)
+					So(err, ShouldBeNil)
+					actual = append(actual, person)
+				}
+				So(block.Size(), ShouldEqual, 0)
+			}
+			So(blockIterator.Err(), ShouldBeNil)
+			So(lengths, ShouldResemble, []int64{30, 30, 30, 10})
+			So(actual, ShouldResemble, people)
+		})
+		Convey("cuts blocks by size", func() {
+			fileWriter, err := avro.NewFileWriter(avro.NewFileWriterInput{
+				Writer:           &buffer,
+				Schema:           parsePersonSchema(),
+				CompressionCodec: avro.CompressionCodecDeflate,
+				MaxBlockSize:     256,
+			})
+			So(err, ShouldBeNil)
+			for i := range people {
+				err = fileWriter.Append(&people[i])
+				So(err, ShouldBeNil)
+			}
+			err = fileWriter.Close()
+			So(err, ShouldBeNil)
+			var header avro.ObjectContainerHeader
+			err = header.ReadAvro(&buffer)
+			So(err, ShouldBeNil)
+			So(string(header.Meta["avro.codec"]), ShouldEqual, "deflate")
+			blockIterator := avro.NewObjectBlockIterator(avro.NewObjectBlockIteratorInput{
+				Reader:       &buffer,
+				ExpectedSync: header.Sync,
+			})
+			var total int64
+			var block avro.ObjectBlock
+			for blockIterator.Next(&block) {
+				So(block.Length, ShouldBeLessThanOrEqualTo, 15)
+				total += block.Length
+			}
+			So(blockIterator.Err(), ShouldBeNil)
+			So(total, ShouldEqual, len(people))
+		})
+		Convey("compression workers", func() {
+			sync := avro.GenerateSync()
+			write := func(workers int) []byte {
+				var buffer bytes.Buffer
+				fileWriter, err := avro.NewFileWriter(avro.NewFileWriterInput{
+					Writer:             &buffer,
+					Schema:             parsePersonSchema(),
+					CompressionCodec:   avro.CompressionCodecDeflate,
+					Sync:               sync,
+					MaxBlockLength:     7,
+					CompressionWorkers: workers,
+					MaxPendingBlocks:   2,
+				})
+				So(err, ShouldBeNil)
+				for i := range people {
+					err = fileWriter.Append(&people[i])
+					So(err, ShouldBeNil)
+					if i == 50 {
+						err = fileWriter.Flush()
+						So(err, ShouldBeNil)
+					}
+				}
+				err = fileWriter.Close()
+				So(err, ShouldBeNil)
+				// the header meta map is written in random order
+				data := buffer.Bytes()
+				return data[bytes.Index(data, sync[:]):]
+			}
+			So(write(4), ShouldResemble, write(0))
+		})
+		Convey("unknown codec", func() {
+			_, err := avro.NewFileWriter(avro.NewFileWriterInput{
+				Writer:           &buffer,
+				Schema:           parsePersonSchema(),
+				CompressionCodec: "unknown",
+			})
+			So(err, ShouldNotBeNil)
+		})
+		Convey("append after close", func() {
+			fileWriter, err := avro.NewFileWriter(avro.NewFileWriterInput{
+				Writer: &buffer,
+				Schema: parsePersonSchema(),
+			})
+			So(err, ShouldBeNil)
+			err = fileWriter.Close()
+			So(err, ShouldBeNil)
+			err = fileWriter.Append(&people[0])
+			So(err, ShouldEqual, avro.ErrFileWriterClosed)
+		})
+	})
+}
@@ -0,0 +1,179 @@
+package avro
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"time"
+)
+
+// ErrValueOutOfRange is returned when a value cannot be represented by its
+// logical type.
+var ErrValueOutOfRange = errors.New("value out of range")
+
+// ErrPrecisionOverflow is returned when a decimal has more digits than the
+// precision or scale of its logical type allow.
+var ErrPrecisionOverflow = errors.New("decimal precision overflow")
+
+var ErrInvalidUUID = errors.New("invalid uuid")
+
+// Duration is the value of the duration logical type, an amount of time in
+// months, days and milliseconds which are independent of each other.
+type Duration struct {
+	Months       uint32
+	Days         uint32
+	Milliseconds uint32
+}
+
+const durationSize = 12
+
+const secondsPerDay = 24 * 60 * 60
+
+// unixTime is the time units of unit after the unix epoch, in UTC.
+func unixTime(units int64, unit time.Duration) time.Time {
+	perSecond := int64(time.Second / unit)
+	seconds := units / perSecond
+	remainder := units % perSecond
+	if remainder < 0 {
+		seconds--
+		remainder += perSecond
+	}
+	return time.Unix(seconds, remainder*int64(unit)).UTC()
+}
+
+// unixUnits is the number of whole units of unit from the unix epoch to
+// value.
+func unixUnits(value time.Time, unit time.Duration) (units int64, err error) {
+	perSecond := int64(time.Second / unit)
+	seconds := value.Unix()
+	fraction := int64(value.Nanosecond()) / int64(unit)
+	if seconds > (math.MaxInt64-fraction)/perSecond || seconds < math.MinInt64/perSecond {
+		err = fmt.Errorf("%w: %s", ErrValueOutOfRange, value)
+		return
+	}
+	units = seconds*perSecond + fraction
+	return
+}
+
+// wallClock is the time in UTC with the same date and clock as value, the
+// representation of local timestamps.
+func wallClock(value time.Time) time.Time {
+	year, month, day := value.Date()
+	hour, minute, second := value.Clock()
+	return time.Date(year, month, day, hour, minute, second, value.Nanosecond(), time.UTC)
+}
+
+// timeOfDayUnits is the number of whole units of unit in value, which must be
+// within a day.
+func timeOfDayUnits(value time.Duration, unit time.Duration) (units int64, err error) {
+	if value < 0 || value >= 24*time.Hour {
+		err = fmt.Errorf("%w: time of day %s", ErrValueOutOfRange, value)
+		return
+	}
+	units = int64(value / unit)
+	return
+}
+
+func timeOfDay(units int64, unit time.Duration) (value time.Duration, err error) {
+	if units < 0 || units >= int64(24*time.Hour/unit) {
+		err = fmt.Errorf("%w: time of day %d", ErrValueOutOfRange, units)
+		return
+	}
+	value = time.Duration(units) * unit
+	return
+}
+
+// decodeTwosComplement is the integer encoded by data as big-endian two's
+// complement.
+func decodeTwosComplement(data []byte, value *big.Int) {
+	value.SetBytes(data)
+	if len(data) > 0 && data[0]&0x80 != 0 {
+		value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(8*len(data))))
+	}
+}
+
+// encodeTwosComplement encodes value as big-endian two's complement in the
+// fewest bytes, or in size bytes if size is greater than zero.
+func encodeTwosComplement(value *big.Int, size int) (data []byte, err error) {
+	var length int
+	if value.Sign() >= 0 {
+		length = value.BitLen()/8 + 1
+	} else {
+		length = new(big.Int).Not(value).BitLen()/8 + 1
+	}
+	if size > 0 {
+		if length > size {
+			err = fmt.Errorf("%w: %s does not fit in %d bytes", ErrValueOutOfRange, value, size)
+			return
+		}
+		length = size
+	}
+	data = make([]byte, length)
+	if value.Sign() < 0 {
+		// the low length bytes of value + 2^(8*length)
+		value = new(big.Int).Add(value, new(big.Int).Lsh(big.NewInt(1), uint(8*length)))
+	}
+	magnitude := value.Bytes()
+	copy(data[length-len(magnitude):], magnitude)
+	return
+}
+
+// unscaledDecimal is value multiplied by 10^scale, which must be an integer
+// of at most precision digits.
+func unscaledDecimal(value *big.Rat, precision int, scale int) (unscaled *big.Int, err error) {
+	scaled := new(big.Rat).Mul(value, new(big.Rat).SetInt(pow10(scale)))
+	if !scaled.IsInt() {
+		err = fmt.Errorf("%w: %s has more than %d decimal places", ErrPrecisionOverflow, value.RatString(), scale)
+		return
+	}
+	unscaled = new(big.Int).Set(scaled.Num())
+	err = checkPrecision(unscaled, precision)
+	return
+}
+
+func checkPrecision(unscaled *big.Int, precision int) error {
+	digits := len(new(big.Int).Abs(unscaled).String())
+	if digits > precision {
+		return fmt.Errorf("%w: %s has more than %d digits", ErrPrecisionOverflow, unscaled, precision)
+	}
+	return nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// readFixed reads a fixed of size bytes, which is checked like the length of
+// bytes so that a negative or huge size returns an error.
+func readFixed(reader io.Reader, size int) (data []byte, err error) {
+	err = DecoderConfigOf(reader).CheckBytesLength(int64(size))
+	if err != nil {
+		return
+	}
+	data = make([]byte, size)
+	_, err = io.ReadFull(reader, data)
+	return
+}
+
+// parseUUID parses the 36 character hyphenated form of a UUID.
+func parseUUID(value string) (uuid [16]byte, err error) {
+	if len(value) != 36 || value[8] != '-' || value[13] != '-' || value[18] != '-' || value[23] != '-' {
+		err = fmt.Errorf("%w: %q", ErrInvalidUUID, value)
+		return
+	}
+	digits := value[:8] + value[9:13] + value[14:18] + value[19:23] + value[24:]
+	_, err = hex.Decode(uuid[:], []byte(digits))
+	if err != nil {
+		err = fmt.Errorf("%w: %q", ErrInvalidUUID, value)
+		return
+	}
+	return
+}
+
+func formatUUID(uuid [16]byte) string {
+	digits := hex.EncodeToString(uuid[:])
+	return digits[:8] + "-" + digits[8:12] + "-" + digits[12:16] + "-" + digits[16:20] + "-" + digits[20:]
+}
@@ -0,0 +1,238 @@
+package avro_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/big"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avro"
+)
+
+func TestLogicalSerialization(t *testing.T) {
+	Convey("TestLogicalSerialization", t, func() {
+		var buffer bytes.Buffer
+		var err error
+		sydney := time.FixedZone("AEST", 10*60*60)
+		Convey("Date", func() {
+			for _, days := range []int32{0, 1, -1, 18262} {
+				_, err = avro.WriteInt(&buffer, days)
+				So(err, ShouldBeNil)
+			}
+			for _, expected := range []time.Time{
+				time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(1970, 1, 2, 0, 0, 0, 0, time.UTC),
+				time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			} {
+				var actual time.Time
+				err = avro.ReadDate(&buffer, &actual)
+				So(err, ShouldBeNil)
+				So(actual, ShouldEqual, expected)
+			}
+			_, err = avro.WriteDate(&buffer, time.Date(2020, 1, 1, 23, 0, 0, 0, sydney))
+			So(err, ShouldBeNil)
+			So(buffer.Bytes(), ShouldResemble, []byte{0xac, 0x9d, 0x02})
+			_, err = avro.WriteDate(&buffer, time.Date(9000000, 1, 1, 0, 0, 0, 0, time.UTC))
+			So(errors.Is(err, avro.ErrValueOutOfRange), ShouldBeTrue)
+		})
+		Convey("Time", func() {
+			expected := 13*time.Hour + 4*time.Minute + 5*time.Second + 6789*time.Microsecond
+			_, err = avro.WriteTimeMillis(&buffer, expected)
+			So(err, ShouldBeNil)
+			_, err = avro.WriteTimeMicros(&buffer, expected)
+			So(err, ShouldBeNil)
+			var actual time.Duration
+			err = avro.ReadTimeMillis(&buffer, &actual)
+			So(err, ShouldBeNil)
+			So(actual, ShouldEqual, expected.Truncate(time.Millisecond))
+			err = avro.ReadTimeMicros(&buffer, &actual)
+			So(err, ShouldBeNil)
+			So(actual, ShouldEqual, expected)
+
+			_, err = avro.WriteTimeMillis(&buffer, 24*time.Hour)
+			So(errors.Is(err, avro.ErrValueOutOfRange), ShouldBeTrue)
+			_, err = avro.WriteTimeMicros(&buffer, -time.Microsecond)
+			So(errors.Is(err, avro.ErrValueOutOfRange), ShouldBeTrue)
+			_, err = avro.WriteInt(&buffer, 24*60*60*1000)
+			So(err, ShouldBeNil)
+			err = avro.ReadTimeMillis(&buffer, &actual)
+			So(errors.Is(err, avro.ErrValueOutOfRange), ShouldBeTrue)
+		})
+		Convey("Timestamp", func() {
+			expected := time.Date(2021, 3, 4, 5, 6, 7, 123456789, time.UTC)
+			for _, c := range []struct {
+				write func(writer io.Writer, value time.Time) (int, error)
+				read  func(reader io.ByteReader, value *time.Time) error
+				unit  time.Duration
+			}{
+				{avro.WriteTimestampMillis, avro.ReadTimestampMillis, time.Millisecond},
+				{avro.WriteTimestampMicros, avro.ReadTimestampMicros, time.Microsecond},
+				{avro.WriteTimestampNanos, avro.ReadTimestampNanos, time.Nanosecond},
+			} {
+				_, err = c.write(&buffer, expected.In(sydney))
+				So(err, ShouldBeNil)
+				var actual time.Time
+				err = c.read(&buffer, &actual)
+				So(err, ShouldBeNil)
+				So(actual, ShouldEqual, expected.Truncate(c.unit))
+				So(actual.Location(), ShouldEqual, time.UTC)
+			}
+
+			_, err = avro.WriteTimestampMillis(&buffer, time.Date(1969, 12, 31, 23, 59, 59, 999000000, time.UTC))
+			So(err, ShouldBeNil)
+			So(buffer.Bytes(), ShouldResemble, []byte{0x01})
+			var actual time.Time
+			err = avro.ReadTimestampMillis(&buffer, &actual)
+			So(err, ShouldBeNil)
+			So(actual, ShouldEqual, time.Date(1969, 12, 31, 23, 59, 59, 999000000, time.UTC))
+
+			_, err = avro.WriteTimestampNanos(&buffer, time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC))
+			So(errors.Is(err, avro.ErrValueOutOfRange), ShouldBeTrue)
+			_, err = avro.WriteTimestampMicros(&buffer, time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC))
+			So(err, ShouldBeNil)
+		})
+		Convey("LocalTimestamp", func() {
+			value := time.Date(2021, 3, 4, 5, 6, 7, 123456789, sydney)
+			_, err = avro.WriteLocalTimestampMillis(&buffer, value)
+			So(err, ShouldBeNil)
+			_, err = avro.WriteLocalTimestampMicros(&buffer, value)
+			So(err, ShouldBeNil)
+			_, err = avro.WriteLocalTimestampNanos(&buffer, value)
+			So(err, ShouldBeNil)
+			expected := time.Date(2021, 3, 4, 5, 6, 7, 123456789, time.UTC)
+			var actual time.Time
+			err = avro.ReadLocalTimestampMillis(&buffer, &actual)
+			So(err, ShouldBeNil)
+			So(actual, ShouldEqual, expected.Truncate(time.Millisecond))
+			err = avro.ReadLocalTimestampMicros(&buffer, &actual)
+			So(err, ShouldBeNil)
+			So(actual, ShouldEqual, expected.Truncate(time.Microsecond))
+			err = avro.ReadLocalTimestampNanos(&buffer, &actual)
+			So(err, ShouldBeNil)
+			So(actual, ShouldEqual, expected)
+		})
+		Convey("Decimal", func() {
+			for _, c := range []struct {
+				value    string
+				expected []byte
+			}{
+				{"0", []byte{0x00}},
+				{"1.27", []byte{0x7f}},
+				{"1.28", []byte{0x00, 0x80}},
+				{"-1.28", []byte{0x80}},
+				{"-1.29", []byte{0xff, 0x7f}},
+				{"123.45", []byte{0x30, 0x39}},
+			} {
+				value, ok := new(big.Rat).SetString(c.value)
+				So(ok, ShouldBeTrue)
+				_, err = avro.WriteDecimal(&buffer, value, 5, 2)
+				So(err, ShouldBeNil)
+				data, err := avro.ReadBytes(bytes.NewReader(buffer.Bytes()))
+				So(err, ShouldBeNil)
+				So(data, ShouldResemble, c.expected)
+				var actual big.Rat
+				err = avro.ReadDecimal(&buffer, &actual, 5, 2)
+				So(err, ShouldBeNil)
+				So(actual.Cmp(value), ShouldEqual, 0)
+			}
+			_, err = avro.WriteDecimal(&buffer, big.NewRat(1234, 1000), 5, 2)
+			So(errors.Is(err, avro.ErrPrecisionOverflow), ShouldBeTrue)
+			_, err = avro.WriteDecimal(&buffer, big.NewRat(12345, 10), 5, 2)
+			So(errors.Is(err, avro.ErrPrecisionOverflow), ShouldBeTrue)
+			So(buffer.Len(), ShouldEqual, 0)
+
+			_, err = avro.WriteDecimal(&buffer, big.NewRat(12345, 1), 7, 2)
+			So(err, ShouldBeNil)
+			var actual big.Rat
+			err = avro.ReadDecimal(&buffer, &actual, 5, 2)
+			So(errors.Is(err, avro.ErrPrecisionOverflow), ShouldBeTrue)
+		})
+		Convey("FixedDecimal", func() {
+			value := big.NewRat(-123456789, 1000)
+			_, err = avro.WriteFixedDecimal(&buffer, value, 8, 18, 3)
+			So(err, ShouldBeNil)
+			So(buffer.Bytes(), ShouldResemble, []byte{0xff, 0xff, 0xff, 0xff, 0xf8, 0xa4, 0x32, 0xeb})
+			var actual big.Rat
+			err = avro.ReadFixedDecimal(&buffer, &actual, 8, 18, 3)
+			So(err, ShouldBeNil)
+			So(actual.Cmp(value), ShouldEqual, 0)
+
+			_, err = avro.WriteUnscaledFixedDecimal(&buffer, big.NewInt(128), 1, 3)
+			So(errors.Is(err, avro.ErrValueOutOfRange), ShouldBeTrue)
+			_, err = avro.WriteUnscaledFixedDecimal(&buffer, big.NewInt(1000), 2, 3)
+			So(errors.Is(err, avro.ErrPrecisionOverflow), ShouldBeTrue)
+			So(buffer.Len(), ShouldEqual, 0)
+
+			_, err = avro.WriteUnscaledFixedDecimal(&buffer, big.NewInt(1000), 2, 4)
+			So(err, ShouldBeNil)
+			err = avro.ReadFixedDecimal(&buffer, &actual, 2, 3, 0)
+			So(errors.Is(err, avro.ErrPrecisionOverflow), ShouldBeTrue)
+
+			err = avro.ReadFixedDecimal(&buffer, &actual, -1, 3, 0)
+			So(err, ShouldEqual, avro.ErrNegativeLength)
+			var unscaled big.Int
+			err = avro.ReadUnscaledFixedDecimal(&buffer, &unscaled, -1, 3)
+			So(err, ShouldEqual, avro.ErrNegativeLength)
+		})
+		Convey("UnscaledDecimal", func() {
+			expected, ok := new(big.Int).SetString("-123456789012345678901234567890", 10)
+			So(ok, ShouldBeTrue)
+			_, err = avro.WriteUnscaledDecimal(&buffer, expected, 30)
+			So(err, ShouldBeNil)
+			_, err = avro.WriteUnscaledFixedDecimal(&buffer, expected, 16, 38)
+			So(err, ShouldBeNil)
+			var actual big.Int
+			err = avro.ReadUnscaledDecimal(&buffer, &actual, 30)
+			So(err, ShouldBeNil)
+			So(actual.Cmp(expected), ShouldEqual, 0)
+			err = avro.ReadUnscaledFixedDecimal(&buffer, &actual, 16, 38)
+			So(err, ShouldBeNil)
+			So(actual.Cmp(expected), ShouldEqual, 0)
+			So(buffer.Len(), ShouldEqual, 0)
+		})
+		Convey("UUID", func() {
+			expected := "123e4567-e89b-12d3-a456-426614174000"
+			_, err = avro.WriteUUID(&buffer, expected)
+			So(err, ShouldBeNil)
+			_, err = avro.WriteFixedUUID(&buffer, expected)
+			So(err, ShouldBeNil)
+			actual, err := avro.ReadUUID(&buffer)
+			So(err, ShouldBeNil)
+			So(actual, ShouldEqual, expected)
+			So(buffer.Len(), ShouldEqual, 16)
+			actual, err = avro.ReadFixedUUID(&buffer)
+			So(err, ShouldBeNil)
+			So(actual, ShouldEqual, expected)
+
+			for _, invalid := range []string{"", "123e4567e89b12d3a456426614174000", "123e4567-e89b-12d3-a456-42661417400g"} {
+				_, err = avro.WriteUUID(&buffer, invalid)
+				So(errors.Is(err, avro.ErrInvalidUUID), ShouldBeTrue)
+			}
+			_, err = avro.WriteString(&buffer, "not a uuid")
+			So(err, ShouldBeNil)
+			_, err = avro.ReadUUID(&buffer)
+			So(errors.Is(err, avro.ErrInvalidUUID), ShouldBeTrue)
+		})
+		Convey("Duration", func() {
+			expected := avro.Duration{
+				Months:       1,
+				Days:         2,
+				Milliseconds: 3,
+			}
+			_, err = avro.WriteDuration(&buffer, expected)
+			So(err, ShouldBeNil)
+			So(buffer.Bytes(), ShouldResemble, []byte{1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0})
+			var actual avro.Duration
+			err = avro.ReadDuration(&buffer, &actual)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, expected)
+			err = avro.ReadDuration(bytes.NewReader(make([]byte, 11)), &actual)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -2,11 +2,8 @@ package avro
 
 import (
 	"bytes"
-	"compress/zlib"
 	"errors"
 	"io"
-
-	"github.com/golang/snappy"
 )
 
 var _ io.Reader = (*ObjectBlock)(nil)
@@ -41,6 +38,10 @@ func (objectBlock *ObjectBlock) WriteByte(c byte) (err error) {
 	return objectBlock.data.WriteByte(c)
 }
 
+func (objectBlock *ObjectBlock) Bytes() []byte {
+	return objectBlock.data.Bytes()
+}
+
 func (objectBlock *ObjectBlock) Size() int {
 	return objectBlock.data.Len()
 }
@@ -59,8 +60,17 @@ func ReadObjectBlock(reader Reader, block *ObjectBlock, expectedSync [16]byte) (
 		}
 		return
 	}
+	config := DecoderConfigOf(reader)
+	if block.Length < 0 {
+		err = errors.New("invalid block length")
+		return
+	}
+	err = config.CheckItemCount(block.Length)
+	if err != nil {
+		return
+	}
 	block.data.Reset()
-	err = ReadBytesIntoBuffer(reader, &block.data)
+	err = readBytesIntoBuffer(reader, &block.data, config.CheckBlockSize)
 	if err != nil {
 		return
 	}
@@ -78,12 +88,16 @@ func ReadObjectBlock(reader Reader, block *ObjectBlock, expectedSync [16]byte) (
 }
 
 func WriteObjectBlock(writer io.Writer, block *ObjectBlock, sync [16]byte) (nWritten int, err error) {
-	n, err := WriteLong(writer, block.Length)
+	return writeObjectBlockData(writer, block.Length, block.data.Bytes(), sync)
+}
+
+func writeObjectBlockData(writer io.Writer, length int64, data []byte, sync [16]byte) (nWritten int, err error) {
+	n, err := WriteLong(writer, length)
 	nWritten += n
 	if err != nil {
 		return
 	}
-	n, err = WriteBytes(writer, block.data.Bytes())
+	n, err = WriteBytes(writer, data)
 	nWritten += n
 	if err != nil {
 		return
@@ -95,16 +109,3 @@ func WriteObjectBlock(writer io.Writer, block *ObjectBlock, sync [16]byte) (nWri
 	}
 	return
 }
-
-func NewCodecWriter(writer io.Writer, codec CompressionCodec) (io.WriteCloser, error) {
-	switch codec {
-	case CompressionCodecNull:
-		return &NoOpCloser{writer}, nil
-	case CompressionCodecDeflate:
-		return zlib.NewWriter(writer), nil
-	case CompressionCodecSnappy:
-		return snappy.NewBufferedWriter(writer), nil
-	default:
-		return nil, errors.New("unknown compression codec")
-	}
-}
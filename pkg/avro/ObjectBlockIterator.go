@@ -1,20 +1,57 @@
 package avro
 
+import (
+	"bytes"
+	"io"
+	"math"
+)
+
 type ObjectBlockIterator struct {
-	reader       Reader
+	reader       *replayReader
+	decoder      *Decoder
 	expectedSync [16]byte
+	recover      func(corruption Corruption)
+	blockOffset  int64
+	end          int64
 	err          error
 }
 
+// Corruption describes a range of bytes skipped by an ObjectBlockIterator
+// while resynchronising on the sync marker.
+type Corruption struct {
+	// Start is the offset of the block that failed to read.
+	Start int64
+	// End is the offset of the next block, or of the end of the file.
+	End int64
+	Err error
+}
+
 type NewObjectBlockIteratorInput struct {
 	Reader       Reader
 	ExpectedSync [16]byte
+	// Offset is the position of Reader within the file, used to report
+	// offsets. It is usually the length of the header.
+	Offset int64
+	// Recover enables resynchronisation. When a block has an invalid length
+	// or sync marker the iterator scans forward for the next sync marker,
+	// calls Recover with the skipped range and resumes from the block that
+	// follows it.
+	Recover func(corruption Corruption)
 }
 
+// NewObjectBlockIterator returns an iterator over the blocks read from
+// input.Reader, limited by its DecoderConfig if it is a Decoder.
 func NewObjectBlockIterator(input NewObjectBlockIteratorInput) *ObjectBlockIterator {
+	reader := &replayReader{
+		reader: input.Reader,
+		offset: input.Offset,
+	}
 	return &ObjectBlockIterator{
-		reader:       input.Reader,
+		reader:       reader,
+		decoder:      NewDecoder(reader, *DecoderConfigOf(input.Reader)),
 		expectedSync: input.ExpectedSync,
+		recover:      input.Recover,
+		end:          math.MaxInt64,
 	}
 }
 
@@ -22,14 +59,157 @@ func (objectBlockIterator *ObjectBlockIterator) Next(block *ObjectBlock) bool {
 	if objectBlockIterator.err != nil {
 		return false
 	}
-	ok, err := ReadObjectBlock(objectBlockIterator.reader, block, objectBlockIterator.expectedSync)
-	if err != nil {
-		objectBlockIterator.err = err
-		return false
+	reader := objectBlockIterator.reader
+	for {
+		if reader.offset >= objectBlockIterator.end {
+			return false
+		}
+		objectBlockIterator.blockOffset = reader.offset
+		if objectBlockIterator.recover != nil {
+			reader.startRecording()
+		}
+		ok, err := ReadObjectBlock(objectBlockIterator.decoder, block, objectBlockIterator.expectedSync)
+		if err == nil {
+			return ok
+		}
+		if objectBlockIterator.recover == nil {
+			objectBlockIterator.err = err
+			return false
+		}
+		if !objectBlockIterator.resynchronise(err) {
+			return false
+		}
 	}
-	return ok
 }
 
 func (objectBlockIterator *ObjectBlockIterator) Err() error {
 	return objectBlockIterator.err
 }
+
+// Offset returns the position in the file following the last block read.
+func (objectBlockIterator *ObjectBlockIterator) Offset() int64 {
+	return objectBlockIterator.reader.offset
+}
+
+// BlockOffset returns the position in the file of the last block read.
+func (objectBlockIterator *ObjectBlockIterator) BlockOffset() int64 {
+	return objectBlockIterator.blockOffset
+}
+
+// resynchronise positions the reader after the next sync marker following the
+// start of the failed block, returning false if the end of the file was
+// reached first.
+func (objectBlockIterator *ObjectBlockIterator) resynchronise(cause error) bool {
+	reader := objectBlockIterator.reader
+	start := objectBlockIterator.blockOffset
+	sync := objectBlockIterator.expectedSync[:]
+	recorded := reader.stopRecording()
+	if len(recorded) > 1 {
+		index := bytes.Index(recorded[1:], sync)
+		if index != -1 {
+			reader.replay(recorded[1+index+len(sync):])
+			objectBlockIterator.recover(Corruption{
+				Start: start,
+				End:   reader.offset,
+				Err:   cause,
+			})
+			return true
+		}
+	}
+	var window []byte
+	if len(recorded) > 1 {
+		window = recorded[1:]
+	}
+	err := scanForSync(reader, sync, window)
+	if err != nil && err != io.EOF {
+		objectBlockIterator.err = err
+	}
+	objectBlockIterator.recover(Corruption{
+		Start: start,
+		End:   reader.offset,
+		Err:   cause,
+	})
+	return err == nil
+}
+
+// scanForSync reads until reader is positioned after the next occurrence of
+// sync. window holds bytes already read which may contain the start of it.
+func scanForSync(reader io.ByteReader, sync []byte, window []byte) error {
+	buffer := make([]byte, 0, 2*len(sync))
+	if len(window) >= len(sync) {
+		window = window[len(window)-len(sync)+1:]
+	}
+	buffer = append(buffer, window...)
+	for {
+		c, err := reader.ReadByte()
+		if err != nil {
+			return err
+		}
+		if len(buffer) == cap(buffer) {
+			buffer = append(buffer[:0], buffer[len(buffer)-len(sync)+1:]...)
+		}
+		buffer = append(buffer, c)
+		if bytes.HasSuffix(buffer, sync) {
+			return nil
+		}
+	}
+}
+
+// replayReader tracks the offset of the underlying reader and can record the
+// bytes read through it so they can be scanned and replayed.
+type replayReader struct {
+	reader    Reader
+	offset    int64
+	pending   []byte
+	recording bool
+	recorded  bytes.Buffer
+}
+
+func (replayReader *replayReader) Read(p []byte) (n int, err error) {
+	if len(replayReader.pending) > 0 {
+		n = copy(p, replayReader.pending)
+		replayReader.pending = replayReader.pending[n:]
+	} else {
+		n, err = replayReader.reader.Read(p)
+	}
+	replayReader.offset += int64(n)
+	if replayReader.recording {
+		replayReader.recorded.Write(p[:n])
+	}
+	return
+}
+
+func (replayReader *replayReader) ReadByte() (c byte, err error) {
+	if len(replayReader.pending) > 0 {
+		c = replayReader.pending[0]
+		replayReader.pending = replayReader.pending[1:]
+	} else {
+		c, err = replayReader.reader.ReadByte()
+		if err != nil {
+			return
+		}
+	}
+	replayReader.offset++
+	if replayReader.recording {
+		replayReader.recorded.WriteByte(c)
+	}
+	return
+}
+
+func (replayReader *replayReader) startRecording() {
+	replayReader.recording = true
+	replayReader.recorded.Reset()
+}
+
+func (replayReader *replayReader) stopRecording() []byte {
+	replayReader.recording = false
+	return replayReader.recorded.Bytes()
+}
+
+// replay pushes data back to be read again ahead of any bytes still pending.
+func (replayReader *replayReader) replay(data []byte) {
+	pending := make([]byte, 0, len(data)+len(replayReader.pending))
+	pending = append(pending, data...)
+	replayReader.pending = append(pending, replayReader.pending...)
+	replayReader.offset -= int64(len(data))
+}
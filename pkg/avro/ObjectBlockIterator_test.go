@@ -2,6 +2,7 @@ package avro_test
 
 import (
 	"bufio"
+	"bytes"
 	"io"
 	"os"
 	"testing"
@@ -22,6 +23,15 @@ func TestObjectBlockIterator(t *testing.T) {
 		Convey("snappy", func() {
 			name = "testdata/people-snappy.avro"
 		})
+		Convey("zstandard", func() {
+			name = "testdata/people-zstandard.avro"
+		})
+		Convey("bzip2", func() {
+			name = "testdata/people-bzip2.avro"
+		})
+		Convey("xz", func() {
+			name = "testdata/people-xz.avro"
+		})
 		file, err := os.Open(name)
 		So(err, ShouldBeNil)
 		defer file.Close()
@@ -44,6 +54,141 @@ func TestObjectBlockIterator(t *testing.T) {
 	})
 }
 
+type testFile struct {
+	data         []byte
+	header       avro.ObjectContainerHeader
+	headerLength int64
+	blockOffsets []int64
+}
+
+func writeTestFile(codec avro.CompressionCodec, nBlocks int, nObjectsPerBlock int) *testFile {
+	var buffer bytes.Buffer
+	fileWriter, err := avro.NewFileWriter(avro.NewFileWriterInput{
+		Writer:           &buffer,
+		Schema:           parsePersonSchema(),
+		CompressionCodec: codec,
+		MaxBlockLength:   int64(nObjectsPerBlock),
+	})
+	if err != nil {
+		panic(err)
+	}
+	people := generatePeople(nBlocks * nObjectsPerBlock)
+	for i := range people {
+		err = fileWriter.Append(&people[i])
+		if err != nil {
+			panic(err)
+		}
+	}
+	err = fileWriter.Close()
+	if err != nil {
+		panic(err)
+	}
+	file := &testFile{
+		data: buffer.Bytes(),
+	}
+	reader := bytes.NewReader(file.data)
+	err = file.header.ReadAvro(reader)
+	if err != nil {
+		panic(err)
+	}
+	file.headerLength = int64(len(file.data) - reader.Len())
+	blockIterator := avro.NewObjectBlockIterator(avro.NewObjectBlockIteratorInput{
+		Reader:       reader,
+		ExpectedSync: file.header.Sync,
+		Offset:       file.headerLength,
+	})
+	var block avro.ObjectBlock
+	for blockIterator.Next(&block) {
+		file.blockOffsets = append(file.blockOffsets, blockIterator.BlockOffset())
+	}
+	if blockIterator.Err() != nil {
+		panic(blockIterator.Err())
+	}
+	return file
+}
+
+func TestObjectBlockIteratorRecover(t *testing.T) {
+	Convey("TestObjectBlockIteratorRecover", t, func() {
+		file := writeTestFile(avro.CompressionCodecNull, 10, 10)
+		So(file.blockOffsets, ShouldHaveLength, 10)
+		var corruptions []avro.Corruption
+		iterate := func() (blockOffsets []int64, err error) {
+			reader := bytes.NewReader(file.data[file.headerLength:])
+			blockIterator := avro.NewObjectBlockIterator(avro.NewObjectBlockIteratorInput{
+				Reader:       reader,
+				ExpectedSync: file.header.Sync,
+				Offset:       file.headerLength,
+				Recover: func(corruption avro.Corruption) {
+					corruptions = append(corruptions, corruption)
+				},
+			})
+			var block avro.ObjectBlock
+			for blockIterator.Next(&block) {
+				So(block.Length, ShouldEqual, 10)
+				blockOffsets = append(blockOffsets, blockIterator.BlockOffset())
+			}
+			err = blockIterator.Err()
+			return
+		}
+		Convey("invalid sync", func() {
+			file.data[file.blockOffsets[4]-1]++
+			blockOffsets, err := iterate()
+			So(err, ShouldBeNil)
+			So(corruptions, ShouldHaveLength, 1)
+			So(corruptions[0].Start, ShouldEqual, file.blockOffsets[3])
+			So(corruptions[0].End, ShouldEqual, file.blockOffsets[5])
+			So(corruptions[0].Err, ShouldNotBeNil)
+			expected := append(append([]int64{}, file.blockOffsets[:3]...), file.blockOffsets[5:]...)
+			So(blockOffsets, ShouldResemble, expected)
+		})
+		Convey("invalid length", func() {
+			file.data[file.blockOffsets[2]] = 1
+			blockOffsets, err := iterate()
+			So(err, ShouldBeNil)
+			So(corruptions, ShouldHaveLength, 1)
+			So(corruptions[0].Start, ShouldEqual, file.blockOffsets[2])
+			So(corruptions[0].End, ShouldEqual, file.blockOffsets[3])
+			expected := append(append([]int64{}, file.blockOffsets[:2]...), file.blockOffsets[3:]...)
+			So(blockOffsets, ShouldResemble, expected)
+		})
+		Convey("oversized block", func() {
+			file.data[file.blockOffsets[6]+1] = 0xfe
+			file.data[file.blockOffsets[6]+2] = 0x7f
+			blockOffsets, err := iterate()
+			So(err, ShouldBeNil)
+			So(corruptions, ShouldHaveLength, 1)
+			So(corruptions[0].Start, ShouldEqual, file.blockOffsets[6])
+			So(corruptions[0].End, ShouldEqual, file.blockOffsets[7])
+			expected := append(append([]int64{}, file.blockOffsets[:6]...), file.blockOffsets[7:]...)
+			So(blockOffsets, ShouldResemble, expected)
+		})
+		Convey("truncated", func() {
+			file.data = file.data[:len(file.data)-20]
+			blockOffsets, err := iterate()
+			So(err, ShouldBeNil)
+			So(corruptions, ShouldHaveLength, 1)
+			So(corruptions[0].Start, ShouldEqual, file.blockOffsets[9])
+			So(corruptions[0].End, ShouldEqual, len(file.data))
+			So(blockOffsets, ShouldResemble, file.blockOffsets[:9])
+		})
+		Convey("without recover", func() {
+			file.data[file.blockOffsets[4]-1]++
+			reader := bytes.NewReader(file.data[file.headerLength:])
+			blockIterator := avro.NewObjectBlockIterator(avro.NewObjectBlockIteratorInput{
+				Reader:       reader,
+				ExpectedSync: file.header.Sync,
+			})
+			count := 0
+			var block avro.ObjectBlock
+			for blockIterator.Next(&block) {
+				count++
+			}
+			So(blockIterator.Err(), ShouldNotBeNil)
+			So(count, ShouldEqual, 3)
+		})
+	})
+}
+
 func benchmarkObjectBlockIterator(b *testing.B, name string) {
 	file, err := os.Open(name)
 	if err != nil {
@@ -83,3 +228,7 @@ func BenchmarkObjectBlockIteratorDeflate(b *testing.B) {
 func BenchmarkObjectBlockIteratorSnappy(b *testing.B) {
 	benchmarkObjectBlockIterator(b, "testdata/people-snappy.avro")
 }
+
+func BenchmarkObjectBlockIteratorZstandard(b *testing.B) {
+	benchmarkObjectBlockIterator(b, "testdata/people-zstandard.avro")
+}
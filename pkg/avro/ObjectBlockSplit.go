@@ -0,0 +1,75 @@
+package avro
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// Split is a byte range [Start, End) of an object container file. A block
+// belongs to the split its first byte falls in.
+type Split struct {
+	Start int64
+	End   int64
+}
+
+// PlanSplits divides a file of size bytes into n splits of near equal size,
+// n must be positive.
+func PlanSplits(size int64, n int) (splits []Split, err error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("cannot plan %d splits", n)
+	}
+	if size < 0 {
+		return nil, ErrNegativeLength
+	}
+	splits = make([]Split, n)
+	for i := range splits {
+		splits[i] = Split{
+			Start: splitOffset(size, i, n),
+			End:   splitOffset(size, i+1, n),
+		}
+	}
+	return
+}
+
+// splitOffset is size * i / n without overflowing.
+func splitOffset(size int64, i int, n int) int64 {
+	quotient, remainder := size/int64(n), size%int64(n)
+	return quotient*int64(i) + remainder*int64(i)/int64(n)
+}
+
+type NewObjectBlockIteratorRangeInput struct {
+	Reader       io.ReadSeeker
+	ExpectedSync [16]byte
+	Split        Split
+}
+
+// NewObjectBlockIteratorRange returns an iterator over the blocks beginning
+// within input.Split. Every block follows a sync marker, either the header's
+// or the previous block's, so the reader is positioned after the first sync
+// marker that ends at or after Split.Start.
+func NewObjectBlockIteratorRange(input NewObjectBlockIteratorRangeInput) (objectBlockIterator *ObjectBlockIterator, err error) {
+	sync := input.ExpectedSync[:]
+	offset := input.Split.Start - int64(len(sync))
+	if offset < 0 {
+		offset = 0
+	}
+	_, err = input.Reader.Seek(offset, io.SeekStart)
+	if err != nil {
+		return
+	}
+	objectBlockIterator = NewObjectBlockIterator(NewObjectBlockIteratorInput{
+		Reader:       bufio.NewReader(input.Reader),
+		ExpectedSync: input.ExpectedSync,
+		Offset:       offset,
+	})
+	objectBlockIterator.end = input.Split.End
+	err = scanForSync(objectBlockIterator.reader, sync, nil)
+	if err == io.EOF {
+		return objectBlockIterator, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return
+}
@@ -0,0 +1,76 @@
+package avro_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avro"
+)
+
+func TestObjectBlockSplit(t *testing.T) {
+	Convey("TestObjectBlockSplit", t, func() {
+		file := writeTestFile(avro.CompressionCodecDeflate, 20, 10)
+		So(file.blockOffsets, ShouldHaveLength, 20)
+		Convey("PlanSplits", func() {
+			splits, err := avro.PlanSplits(100, 3)
+			So(err, ShouldBeNil)
+			So(splits, ShouldResemble, []avro.Split{
+				{Start: 0, End: 33},
+				{Start: 33, End: 66},
+				{Start: 66, End: 100},
+			})
+			splits, err = avro.PlanSplits(math.MaxInt64, 4)
+			So(err, ShouldBeNil)
+			So(splits[3].End, ShouldEqual, int64(math.MaxInt64))
+			_, err = avro.PlanSplits(100, 0)
+			So(err, ShouldNotBeNil)
+			_, err = avro.PlanSplits(100, -1)
+			So(err, ShouldNotBeNil)
+		})
+		Convey("every block is read exactly once", func() {
+			for n := 1; n <= 64; n *= 2 {
+				var blockOffsets []int64
+				splits, err := avro.PlanSplits(int64(len(file.data)), n)
+				So(err, ShouldBeNil)
+				for _, split := range splits {
+					blockIterator, err := avro.NewObjectBlockIteratorRange(avro.NewObjectBlockIteratorRangeInput{
+						Reader:       bytes.NewReader(file.data),
+						ExpectedSync: file.header.Sync,
+						Split:        split,
+					})
+					So(err, ShouldBeNil)
+					var block avro.ObjectBlock
+					for blockIterator.Next(&block) {
+						So(blockIterator.BlockOffset(), ShouldBeGreaterThanOrEqualTo, split.Start)
+						So(blockIterator.BlockOffset(), ShouldBeLessThan, split.End)
+						So(block.Length, ShouldEqual, 10)
+						blockOffsets = append(blockOffsets, blockIterator.BlockOffset())
+					}
+					So(blockIterator.Err(), ShouldBeNil)
+				}
+				So(blockOffsets, ShouldResemble, file.blockOffsets)
+			}
+		})
+		Convey("split starting at a block", func() {
+			blockIterator, err := avro.NewObjectBlockIteratorRange(avro.NewObjectBlockIteratorRangeInput{
+				Reader:       bytes.NewReader(file.data),
+				ExpectedSync: file.header.Sync,
+				Split: avro.Split{
+					Start: file.blockOffsets[3],
+					End:   file.blockOffsets[5],
+				},
+			})
+			So(err, ShouldBeNil)
+			var blockOffsets []int64
+			var block avro.ObjectBlock
+			for blockIterator.Next(&block) {
+				blockOffsets = append(blockOffsets, blockIterator.BlockOffset())
+			}
+			So(blockIterator.Err(), ShouldBeNil)
+			So(blockOffsets, ShouldResemble, file.blockOffsets[3:5])
+		})
+	})
+}
@@ -6,12 +6,20 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"strconv"
+	"strings"
 
 	"github.com/Ryan-A-B/avro-go/pkg/avroschema"
 )
 
 var expectedMagic = [4]byte{0x4f, 0x62, 0x6a, 0x01}
 
+var ErrReservedMetaKey = errors.New("meta keys beginning with avro. are reserved")
+
+var ErrMetaKeyNotFound = errors.New("meta key not found")
+
+const reservedMetaPrefix = "avro."
+
 type ObjectContainerHeader struct {
 	Meta map[string][]byte `avro:"meta"`
 	Sync [16]byte          `avro:"sync"`
@@ -21,28 +29,43 @@ type NewObjectContainerHeaderInput struct {
 	Schema           avroschema.Schema
 	CompressionCodec CompressionCodec
 	Sync             [16]byte
+	// Meta is user metadata to include in the header. Keys beginning with
+	// avro. are reserved.
+	Meta map[string][]byte
 }
 
 type CompressionCodec string
 
 const (
-	CompressionCodecNull    CompressionCodec = "null"
-	CompressionCodecDeflate CompressionCodec = "deflate"
-	CompressionCodecSnappy  CompressionCodec = "snappy"
+	CompressionCodecNull      CompressionCodec = "null"
+	CompressionCodecDeflate   CompressionCodec = "deflate"
+	CompressionCodecSnappy    CompressionCodec = "snappy"
+	CompressionCodecZstandard CompressionCodec = "zstandard"
+	CompressionCodecBzip2     CompressionCodec = "bzip2"
+	CompressionCodecXZ        CompressionCodec = "xz"
 )
 
-func NewObjectContainerHeader(input NewObjectContainerHeaderInput) *ObjectContainerHeader {
-	meta := make(map[string][]byte)
+// NewObjectContainerHeader returns the header of a file of input.Schema
+// records compressed with input.CompressionCodec, returning
+// ErrReservedMetaKey if input.Meta has a key beginning with avro.
+func NewObjectContainerHeader(input NewObjectContainerHeaderInput) (header *ObjectContainerHeader, err error) {
 	schema, err := json.Marshal(input.Schema)
 	if err != nil {
-		panic(err)
+		return
 	}
-	meta["avro.schema"] = schema
-	meta["avro.codec"] = []byte(input.CompressionCodec)
-	return &ObjectContainerHeader{
-		Meta: meta,
+	header = &ObjectContainerHeader{
+		Meta: make(map[string][]byte, len(input.Meta)+2),
 		Sync: input.Sync,
 	}
+	for key, value := range input.Meta {
+		err = header.SetBytes(key, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	header.Meta["avro.schema"] = schema
+	header.Meta["avro.codec"] = []byte(input.CompressionCodec)
+	return
 }
 
 func GenerateSync() (sync [16]byte) {
@@ -71,6 +94,10 @@ func (header *ObjectContainerHeader) ReadAvro(reader Reader) (err error) {
 		err = errors.New("missing avro.schema")
 		return
 	}
+	_, err = LookupCodec(header.CompressionCodec())
+	if err != nil {
+		return
+	}
 	_, err = io.ReadFull(reader, header.Sync[:])
 	if err != nil {
 		return
@@ -78,6 +105,91 @@ func (header *ObjectContainerHeader) ReadAvro(reader Reader) (err error) {
 	return
 }
 
+// CompressionCodec returns the header's avro.codec, which defaults to null
+// when absent.
+func (header *ObjectContainerHeader) CompressionCodec() CompressionCodec {
+	codec, ok := header.Meta["avro.codec"]
+	if !ok {
+		return CompressionCodecNull
+	}
+	return CompressionCodec(codec)
+}
+
+// Schema parses the header's avro.schema.
+func (header *ObjectContainerHeader) Schema() (avroschema.Schema, error) {
+	return avroschema.ParseSchema(header.Meta["avro.schema"])
+}
+
+// Codec looks up the codec named by the header's avro.codec.
+func (header *ObjectContainerHeader) Codec() (Codec, error) {
+	return LookupCodec(header.CompressionCodec())
+}
+
+// SetBytes sets the user metadata key, returning ErrReservedMetaKey for keys
+// beginning with avro.
+func (header *ObjectContainerHeader) SetBytes(key string, value []byte) error {
+	if strings.HasPrefix(key, reservedMetaPrefix) {
+		return ErrReservedMetaKey
+	}
+	if header.Meta == nil {
+		header.Meta = make(map[string][]byte)
+	}
+	header.Meta[key] = value
+	return nil
+}
+
+// GetBytes returns the metadata key, or ErrMetaKeyNotFound.
+func (header *ObjectContainerHeader) GetBytes(key string) ([]byte, error) {
+	value, ok := header.Meta[key]
+	if !ok {
+		return nil, ErrMetaKeyNotFound
+	}
+	return value, nil
+}
+
+func (header *ObjectContainerHeader) SetString(key string, value string) error {
+	return header.SetBytes(key, []byte(value))
+}
+
+func (header *ObjectContainerHeader) GetString(key string) (string, error) {
+	value, err := header.GetBytes(key)
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+// SetInt64 stores value in decimal, as other avro implementations do.
+func (header *ObjectContainerHeader) SetInt64(key string, value int64) error {
+	return header.SetBytes(key, strconv.AppendInt(nil, value, 10))
+}
+
+func (header *ObjectContainerHeader) GetInt64(key string) (int64, error) {
+	value, err := header.GetBytes(key)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(string(value), 10, 64)
+}
+
+// SetJSON stores value encoded with json.Marshal.
+func (header *ObjectContainerHeader) SetJSON(key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return header.SetBytes(key, data)
+}
+
+// GetJSON decodes the metadata key into value with json.Unmarshal.
+func (header *ObjectContainerHeader) GetJSON(key string, value interface{}) error {
+	data, err := header.GetBytes(key)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, value)
+}
+
 func (header *ObjectContainerHeader) WriteAvro(writer io.Writer) (err error) {
 	_, err = writer.Write(expectedMagic[:])
 	if err != nil {
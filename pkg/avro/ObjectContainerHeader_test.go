@@ -0,0 +1,83 @@
+package avro_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avro"
+)
+
+func TestObjectContainerHeader(t *testing.T) {
+	Convey("TestObjectContainerHeader", t, func() {
+		header, err := avro.NewObjectContainerHeader(avro.NewObjectContainerHeaderInput{
+			Schema:           parsePersonSchema(),
+			CompressionCodec: avro.CompressionCodecSnappy,
+			Sync:             avro.GenerateSync(),
+			Meta: map[string][]byte{
+				"source": []byte("ingest"),
+			},
+		})
+		So(err, ShouldBeNil)
+		Convey("reserved keys", func() {
+			So(header.CompressionCodec(), ShouldEqual, avro.CompressionCodecSnappy)
+			err := header.SetString("avro.codec", "null")
+			So(err, ShouldEqual, avro.ErrReservedMetaKey)
+			So(header.CompressionCodec(), ShouldEqual, avro.CompressionCodecSnappy)
+			meta := map[string][]byte{
+				"avro.codec": []byte("null"),
+			}
+			_, err = avro.NewObjectContainerHeader(avro.NewObjectContainerHeaderInput{
+				Schema:           parsePersonSchema(),
+				CompressionCodec: avro.CompressionCodecSnappy,
+				Meta:             meta,
+			})
+			So(err, ShouldEqual, avro.ErrReservedMetaKey)
+			var buffer bytes.Buffer
+			_, err = avro.NewFileWriter(avro.NewFileWriterInput{
+				Writer:           &buffer,
+				Schema:           parsePersonSchema(),
+				CompressionCodec: avro.CompressionCodecSnappy,
+				Meta:             meta,
+			})
+			So(err, ShouldEqual, avro.ErrReservedMetaKey)
+			So(buffer.Len(), ShouldEqual, 0)
+		})
+		Convey("typed metadata", func() {
+			err := header.SetInt64("rows", -42)
+			So(err, ShouldBeNil)
+			err = header.SetJSON("tags", map[string]string{"team": "data"})
+			So(err, ShouldBeNil)
+			var buffer bytes.Buffer
+			err = header.WriteAvro(&buffer)
+			So(err, ShouldBeNil)
+			var actual avro.ObjectContainerHeader
+			err = actual.ReadAvro(&buffer)
+			So(err, ShouldBeNil)
+			source, err := actual.GetString("source")
+			So(err, ShouldBeNil)
+			So(source, ShouldEqual, "ingest")
+			rows, err := actual.GetInt64("rows")
+			So(err, ShouldBeNil)
+			So(rows, ShouldEqual, -42)
+			var tags map[string]string
+			err = actual.GetJSON("tags", &tags)
+			So(err, ShouldBeNil)
+			So(tags, ShouldResemble, map[string]string{"team": "data"})
+			_, err = actual.GetString("missing")
+			So(err, ShouldEqual, avro.ErrMetaKeyNotFound)
+			schema, err := actual.Schema()
+			So(err, ShouldBeNil)
+			expected, err := json.Marshal(parsePersonSchema())
+			So(err, ShouldBeNil)
+			data, err := json.Marshal(schema)
+			So(err, ShouldBeNil)
+			So(data, ShouldResemble, expected)
+			codec, err := actual.Codec()
+			So(err, ShouldBeNil)
+			So(codec.Name(), ShouldEqual, avro.CompressionCodecSnappy)
+		})
+	})
+}
@@ -3,8 +3,10 @@ package avro_test
 import (
 	"bufio"
 	"io"
+	"io/ioutil"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -47,22 +49,37 @@ func (person *Person) ReadAvro(reader avro.Reader) error {
 func TestCreateObjectContainerFile(t *testing.T) {
 	nBlocks := 16
 	nObjectsPerBlock := 1024
+	// the files in testdata are fixtures for the reader tests, so are not
+	// overwritten here
+	dir, err := ioutil.TempDir("", "avro")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
 	Convey("TestCreateObjectContainerFile", t, func() {
 		var name string
 		var codec avro.CompressionCodec
 		Convey("null", func() {
-			name = "testdata/people-null.avro"
+			name = "people-null.avro"
 			codec = avro.CompressionCodecNull
 		})
 		Convey("deflate", func() {
-			name = "testdata/people-deflate.avro"
+			name = "people-deflate.avro"
 			codec = avro.CompressionCodecDeflate
 		})
 		Convey("snappy", func() {
-			name = "testdata/people-snappy.avro"
+			name = "people-snappy.avro"
 			codec = avro.CompressionCodecSnappy
 		})
-		file, err := os.Create(name)
+		Convey("zstandard", func() {
+			name = "people-zstandard.avro"
+			codec = avro.CompressionCodecZstandard
+		})
+		Convey("xz", func() {
+			name = "people-xz.avro"
+			codec = avro.CompressionCodecXZ
+		})
+		file, err := os.Create(filepath.Join(dir, name))
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -80,10 +97,13 @@ func TestCreateObjectContainerFile(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		header := avro.NewObjectContainerHeader(avro.NewObjectContainerHeaderInput{
+		header, err := avro.NewObjectContainerHeader(avro.NewObjectContainerHeaderInput{
 			Schema:           schema,
 			CompressionCodec: codec,
 		})
+		if err != nil {
+			t.Fatal(err)
+		}
 		err = header.WriteAvro(bufferedFileWriter)
 		if err != nil {
 			t.Fatal(err)
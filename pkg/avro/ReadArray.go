@@ -3,23 +3,27 @@ package avro
 type ReadItemFunc func(int) error
 
 func ReadArray(reader Reader, readItem ReadItemFunc) error {
-	var err error
-	var length int64
-	err = ReadLong(reader, &length)
-	if err != nil {
-		return err
-	}
-	for length > 0 {
-		for i := 0; i < int(length); i++ {
-			err = readItem(i)
+	return ReadNested(reader, func() error {
+		var total int64
+		length, err := readItemCount(reader, &total)
+		if err != nil {
+			return err
+		}
+		// items are numbered across blocks
+		var index int
+		for length > 0 {
+			for i := 0; i < int(length); i++ {
+				err = readItem(index)
+				if err != nil {
+					return err
+				}
+				index++
+			}
+			length, err = readItemCount(reader, &total)
 			if err != nil {
 				return err
 			}
 		}
-		err = ReadLong(reader, &length)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+		return nil
+	})
 }
@@ -0,0 +1,168 @@
+package avro
+
+import (
+	"encoding/binary"
+	"io"
+	"math/big"
+	"time"
+)
+
+// ReadDate reads a date, the number of days since the unix epoch, as
+// midnight UTC.
+func ReadDate(reader io.ByteReader, value *time.Time) (err error) {
+	var days int32
+	err = ReadInt(reader, &days)
+	if err != nil {
+		return
+	}
+	*value = time.Unix(int64(days)*secondsPerDay, 0).UTC()
+	return
+}
+
+// ReadTimeMillis reads a time-millis as the time since midnight.
+func ReadTimeMillis(reader io.ByteReader, value *time.Duration) (err error) {
+	var millis int32
+	err = ReadInt(reader, &millis)
+	if err != nil {
+		return
+	}
+	*value, err = timeOfDay(int64(millis), time.Millisecond)
+	return
+}
+
+// ReadTimeMicros reads a time-micros as the time since midnight.
+func ReadTimeMicros(reader io.ByteReader, value *time.Duration) (err error) {
+	var micros int64
+	err = ReadLong(reader, &micros)
+	if err != nil {
+		return
+	}
+	*value, err = timeOfDay(micros, time.Microsecond)
+	return
+}
+
+func ReadTimestampMillis(reader io.ByteReader, value *time.Time) error {
+	return readTimestamp(reader, value, time.Millisecond)
+}
+
+func ReadTimestampMicros(reader io.ByteReader, value *time.Time) error {
+	return readTimestamp(reader, value, time.Microsecond)
+}
+
+func ReadTimestampNanos(reader io.ByteReader, value *time.Time) error {
+	return readTimestamp(reader, value, time.Nanosecond)
+}
+
+// ReadLocalTimestampMillis reads a local-timestamp-millis as its date and
+// clock in UTC.
+func ReadLocalTimestampMillis(reader io.ByteReader, value *time.Time) error {
+	return readTimestamp(reader, value, time.Millisecond)
+}
+
+// ReadLocalTimestampMicros reads a local-timestamp-micros as its date and
+// clock in UTC.
+func ReadLocalTimestampMicros(reader io.ByteReader, value *time.Time) error {
+	return readTimestamp(reader, value, time.Microsecond)
+}
+
+// ReadLocalTimestampNanos reads a local-timestamp-nanos as its date and
+// clock in UTC.
+func ReadLocalTimestampNanos(reader io.ByteReader, value *time.Time) error {
+	return readTimestamp(reader, value, time.Nanosecond)
+}
+
+func readTimestamp(reader io.ByteReader, value *time.Time, unit time.Duration) (err error) {
+	var units int64
+	err = ReadLong(reader, &units)
+	if err != nil {
+		return
+	}
+	*value = unixTime(units, unit)
+	return
+}
+
+// ReadDecimal reads a decimal on bytes with the given precision and scale,
+// returning ErrPrecisionOverflow if it has more than precision digits.
+func ReadDecimal(reader Reader, value *big.Rat, precision int, scale int) (err error) {
+	var unscaled big.Int
+	err = ReadUnscaledDecimal(reader, &unscaled, precision)
+	if err != nil {
+		return
+	}
+	value.SetFrac(&unscaled, pow10(scale))
+	return
+}
+
+// ReadFixedDecimal reads a decimal on a fixed of size bytes with the given
+// precision and scale, returning ErrPrecisionOverflow if it has more than
+// precision digits.
+func ReadFixedDecimal(reader io.Reader, value *big.Rat, size int, precision int, scale int) (err error) {
+	var unscaled big.Int
+	err = ReadUnscaledFixedDecimal(reader, &unscaled, size, precision)
+	if err != nil {
+		return
+	}
+	value.SetFrac(&unscaled, pow10(scale))
+	return
+}
+
+// ReadUnscaledDecimal reads a decimal on bytes as its unscaled integer
+// value, which must have at most precision digits.
+func ReadUnscaledDecimal(reader Reader, value *big.Int, precision int) (err error) {
+	data, err := ReadBytes(reader)
+	if err != nil {
+		return
+	}
+	decodeTwosComplement(data, value)
+	return checkPrecision(value, precision)
+}
+
+// ReadUnscaledFixedDecimal reads a decimal on a fixed of size bytes as its
+// unscaled integer value, which must have at most precision digits.
+func ReadUnscaledFixedDecimal(reader io.Reader, value *big.Int, size int, precision int) (err error) {
+	data, err := readFixed(reader, size)
+	if err != nil {
+		return
+	}
+	decodeTwosComplement(data, value)
+	return checkPrecision(value, precision)
+}
+
+// ReadUUID reads a uuid on a string, which must be in the 36 character
+// hyphenated form.
+func ReadUUID(reader Reader) (value string, err error) {
+	value, err = ReadString(reader)
+	if err != nil {
+		return
+	}
+	_, err = parseUUID(value)
+	if err != nil {
+		return
+	}
+	return
+}
+
+// ReadFixedUUID reads a uuid on a fixed of 16 bytes in the 36 character
+// hyphenated form.
+func ReadFixedUUID(reader io.Reader) (value string, err error) {
+	var uuid [16]byte
+	_, err = io.ReadFull(reader, uuid[:])
+	if err != nil {
+		return
+	}
+	value = formatUUID(uuid)
+	return
+}
+
+func ReadDuration(reader io.Reader, value *Duration) (err error) {
+	data, err := readFixed(reader, durationSize)
+	if err != nil {
+		return
+	}
+	*value = Duration{
+		Months:       binary.LittleEndian.Uint32(data[0:4]),
+		Days:         binary.LittleEndian.Uint32(data[4:8]),
+		Milliseconds: binary.LittleEndian.Uint32(data[8:12]),
+	}
+	return
+}
@@ -48,5 +48,5 @@ func ReadOptional(reader Reader, read func() error) error {
 	if !present {
 		return nil
 	}
-	return read()
+	return ReadNested(reader, read)
 }
@@ -49,8 +49,7 @@ func ReadDouble(reader io.Reader, value *float64) (err error) {
 }
 
 func ReadBytes(reader Reader) (value []byte, err error) {
-	var length int64
-	err = ReadLong(reader, &length)
+	length, err := readLength(reader, DecoderConfigOf(reader).CheckBytesLength)
 	if err != nil {
 		return
 	}
@@ -72,8 +71,11 @@ func ReadString(reader Reader) (value string, err error) {
 }
 
 func ReadBytesIntoBuffer(reader Reader, buffer *bytes.Buffer) (err error) {
-	var length int64
-	err = ReadLong(reader, &length)
+	return readBytesIntoBuffer(reader, buffer, DecoderConfigOf(reader).CheckBytesLength)
+}
+
+func readBytesIntoBuffer(reader Reader, buffer *bytes.Buffer, check func(int64) error) (err error) {
+	length, err := readLength(reader, check)
 	if err != nil {
 		return
 	}
@@ -1,16 +1,19 @@
 package avro
 
+import "errors"
+
 func ReadDoubleArray(reader Reader, values []float64) (err error) {
-	var length int64
-	err = ReadLong(reader, &length)
+	var total int64
+	length, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
 	totalLength := 0
 	for length > 0 {
+		offset := totalLength
 		totalLength += int(length)
 		if totalLength > len(values) {
-			panic("array length exceeds slice length")
+			return errors.New("array length exceeds slice length")
 		}
 		for i := 0; i < int(length); i++ {
 			var value float64
@@ -18,15 +21,15 @@ func ReadDoubleArray(reader Reader, values []float64) (err error) {
 			if err != nil {
 				return
 			}
-			values[i] = value
+			values[offset+i] = value
 		}
-		err = ReadLong(reader, &length)
+		length, err = readItemCount(reader, &total)
 		if err != nil {
 			return err
 		}
 	}
 	if totalLength != len(values) {
-		panic("array length does not match slice length")
+		return errors.New("array length does not match slice length")
 	}
 	return
 }
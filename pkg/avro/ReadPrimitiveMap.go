@@ -1,12 +1,12 @@
 package avro
 
 func ReadBooleanMap(reader Reader, values *map[string]bool) (err error) {
-	var length int64
-	err = ReadLong(reader, &length)
+	var total int64
+	length, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
-	*values = make(map[string]bool, length)
+	*values = make(map[string]bool, initialCapacity(length))
 	for length > 0 {
 		for i := int64(0); i < length; i++ {
 			var key string
@@ -21,7 +21,7 @@ func ReadBooleanMap(reader Reader, values *map[string]bool) (err error) {
 			}
 			(*values)[key] = value
 		}
-		err = ReadLong(reader, &length)
+		length, err = readItemCount(reader, &total)
 		if err != nil {
 			return
 		}
@@ -30,12 +30,12 @@ func ReadBooleanMap(reader Reader, values *map[string]bool) (err error) {
 }
 
 func ReadIntMap(reader Reader, values *map[string]int32) (err error) {
-	var length int64
-	err = ReadLong(reader, &length)
+	var total int64
+	length, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
-	*values = make(map[string]int32, length)
+	*values = make(map[string]int32, initialCapacity(length))
 	for length > 0 {
 		for i := int64(0); i < length; i++ {
 			var key string
@@ -50,7 +50,7 @@ func ReadIntMap(reader Reader, values *map[string]int32) (err error) {
 			}
 			(*values)[key] = value
 		}
-		err = ReadLong(reader, &length)
+		length, err = readItemCount(reader, &total)
 		if err != nil {
 			return
 		}
@@ -59,12 +59,12 @@ func ReadIntMap(reader Reader, values *map[string]int32) (err error) {
 }
 
 func ReadLongMap(reader Reader, values *map[string]int64) (err error) {
-	var length int64
-	err = ReadLong(reader, &length)
+	var total int64
+	length, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
-	*values = make(map[string]int64, length)
+	*values = make(map[string]int64, initialCapacity(length))
 	for length > 0 {
 		for i := int64(0); i < length; i++ {
 			var key string
@@ -79,7 +79,7 @@ func ReadLongMap(reader Reader, values *map[string]int64) (err error) {
 			}
 			(*values)[key] = value
 		}
-		err = ReadLong(reader, &length)
+		length, err = readItemCount(reader, &total)
 		if err != nil {
 			return
 		}
@@ -88,12 +88,12 @@ func ReadLongMap(reader Reader, values *map[string]int64) (err error) {
 }
 
 func ReadFloatMap(reader Reader, values *map[string]float32) (err error) {
-	var length int64
-	err = ReadLong(reader, &length)
+	var total int64
+	length, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
-	*values = make(map[string]float32, length)
+	*values = make(map[string]float32, initialCapacity(length))
 	for length > 0 {
 		for i := int64(0); i < length; i++ {
 			var key string
@@ -108,7 +108,7 @@ func ReadFloatMap(reader Reader, values *map[string]float32) (err error) {
 			}
 			(*values)[key] = value
 		}
-		err = ReadLong(reader, &length)
+		length, err = readItemCount(reader, &total)
 		if err != nil {
 			return
 		}
@@ -117,12 +117,12 @@ func ReadFloatMap(reader Reader, values *map[string]float32) (err error) {
 }
 
 func ReadDoubleMap(reader Reader, values *map[string]float64) (err error) {
-	var length int64
-	err = ReadLong(reader, &length)
+	var total int64
+	length, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
-	*values = make(map[string]float64, length)
+	*values = make(map[string]float64, initialCapacity(length))
 	for length > 0 {
 		for i := int64(0); i < length; i++ {
 			var key string
@@ -137,7 +137,7 @@ func ReadDoubleMap(reader Reader, values *map[string]float64) (err error) {
 			}
 			(*values)[key] = value
 		}
-		err = ReadLong(reader, &length)
+		length, err = readItemCount(reader, &total)
 		if err != nil {
 			return
 		}
@@ -146,12 +146,12 @@ func ReadDoubleMap(reader Reader, values *map[string]float64) (err error) {
 }
 
 func ReadBytesMap(reader Reader, values *map[string][]byte) (err error) {
-	var length int64
-	err = ReadLong(reader, &length)
+	var total int64
+	length, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
-	*values = make(map[string][]byte, length)
+	*values = make(map[string][]byte, initialCapacity(length))
 	for length > 0 {
 		for i := int64(0); i < length; i++ {
 			var key string
@@ -166,7 +166,7 @@ func ReadBytesMap(reader Reader, values *map[string][]byte) (err error) {
 			}
 			(*values)[key] = value
 		}
-		err = ReadLong(reader, &length)
+		length, err = readItemCount(reader, &total)
 		if err != nil {
 			return
 		}
@@ -175,12 +175,12 @@ func ReadBytesMap(reader Reader, values *map[string][]byte) (err error) {
 }
 
 func ReadStringMap(reader Reader, values *map[string]string) (err error) {
-	var length int64
-	err = ReadLong(reader, &length)
+	var total int64
+	length, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
-	*values = make(map[string]string, length)
+	*values = make(map[string]string, initialCapacity(length))
 	for length > 0 {
 		for i := int64(0); i < length; i++ {
 			var key string
@@ -195,7 +195,7 @@ func ReadStringMap(reader Reader, values *map[string]string) (err error) {
 			}
 			(*values)[key] = value
 		}
-		err = ReadLong(reader, &length)
+		length, err = readItemCount(reader, &total)
 		if err != nil {
 			return
 		}
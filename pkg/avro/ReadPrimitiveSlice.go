@@ -1,12 +1,12 @@
 package avro
 
 func ReadBooleanSlice(reader Reader, values *[]bool) (err error) {
-	var length int64
-	err = ReadLong(reader, &length)
+	var total int64
+	length, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
-	*values = make([]bool, length)
+	*values = make([]bool, 0, initialCapacity(length))
 	for length > 0 {
 		for i := 0; i < int(length); i++ {
 			var value bool
@@ -14,9 +14,9 @@ func ReadBooleanSlice(reader Reader, values *[]bool) (err error) {
 			if err != nil {
 				return
 			}
-			(*values)[i] = value
+			*values = append(*values, value)
 		}
-		err = ReadLong(reader, &length)
+		length, err = readItemCount(reader, &total)
 		if err != nil {
 			return err
 		}
@@ -25,12 +25,12 @@ func ReadBooleanSlice(reader Reader, values *[]bool) (err error) {
 }
 
 func ReadIntSlice(reader Reader, values *[]int32) (err error) {
-	var length int64
-	err = ReadLong(reader, &length)
+	var total int64
+	length, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
-	*values = make([]int32, length)
+	*values = make([]int32, 0, initialCapacity(length))
 	for length > 0 {
 		for i := 0; i < int(length); i++ {
 			var value int32
@@ -38,9 +38,9 @@ func ReadIntSlice(reader Reader, values *[]int32) (err error) {
 			if err != nil {
 				return
 			}
-			(*values)[i] = value
+			*values = append(*values, value)
 		}
-		err = ReadLong(reader, &length)
+		length, err = readItemCount(reader, &total)
 		if err != nil {
 			return err
 		}
@@ -49,12 +49,12 @@ func ReadIntSlice(reader Reader, values *[]int32) (err error) {
 }
 
 func ReadLongSlice(reader Reader, values *[]int64) (err error) {
-	var length int64
-	err = ReadLong(reader, &length)
+	var total int64
+	length, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
-	*values = make([]int64, length)
+	*values = make([]int64, 0, initialCapacity(length))
 	for length > 0 {
 		for i := 0; i < int(length); i++ {
 			var value int64
@@ -62,9 +62,9 @@ func ReadLongSlice(reader Reader, values *[]int64) (err error) {
 			if err != nil {
 				return
 			}
-			(*values)[i] = value
+			*values = append(*values, value)
 		}
-		err = ReadLong(reader, &length)
+		length, err = readItemCount(reader, &total)
 		if err != nil {
 			return err
 		}
@@ -73,12 +73,12 @@ func ReadLongSlice(reader Reader, values *[]int64) (err error) {
 }
 
 func ReadFloatSlice(reader Reader, values *[]float32) (err error) {
-	var length int64
-	err = ReadLong(reader, &length)
+	var total int64
+	length, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
-	*values = make([]float32, length)
+	*values = make([]float32, 0, initialCapacity(length))
 	for length > 0 {
 		for i := 0; i < int(length); i++ {
 			var value float32
@@ -86,9 +86,9 @@ func ReadFloatSlice(reader Reader, values *[]float32) (err error) {
 			if err != nil {
 				return
 			}
-			(*values)[i] = value
+			*values = append(*values, value)
 		}
-		err = ReadLong(reader, &length)
+		length, err = readItemCount(reader, &total)
 		if err != nil {
 			return err
 		}
@@ -97,12 +97,12 @@ func ReadFloatSlice(reader Reader, values *[]float32) (err error) {
 }
 
 func ReadDoubleSlice(reader Reader, values *[]float64) (err error) {
-	var length int64
-	err = ReadLong(reader, &length)
+	var total int64
+	length, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
-	*values = make([]float64, length)
+	*values = make([]float64, 0, initialCapacity(length))
 	for length > 0 {
 		for i := 0; i < int(length); i++ {
 			var value float64
@@ -110,9 +110,9 @@ func ReadDoubleSlice(reader Reader, values *[]float64) (err error) {
 			if err != nil {
 				return
 			}
-			(*values)[i] = value
+			*values = append(*values, value)
 		}
-		err = ReadLong(reader, &length)
+		length, err = readItemCount(reader, &total)
 		if err != nil {
 			return err
 		}
@@ -121,12 +121,12 @@ func ReadDoubleSlice(reader Reader, values *[]float64) (err error) {
 }
 
 func ReadBytesSlice(reader Reader, values *[][]byte) (err error) {
-	var length int64
-	err = ReadLong(reader, &length)
+	var total int64
+	length, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
-	*values = make([][]byte, length)
+	*values = make([][]byte, 0, initialCapacity(length))
 	for length > 0 {
 		for i := 0; i < int(length); i++ {
 			var value []byte
@@ -134,9 +134,9 @@ func ReadBytesSlice(reader Reader, values *[][]byte) (err error) {
 			if err != nil {
 				return
 			}
-			(*values)[i] = value
+			*values = append(*values, value)
 		}
-		err = ReadLong(reader, &length)
+		length, err = readItemCount(reader, &total)
 		if err != nil {
 			return err
 		}
@@ -145,12 +145,12 @@ func ReadBytesSlice(reader Reader, values *[][]byte) (err error) {
 }
 
 func ReadStringSlice(reader Reader, values *[]string) (err error) {
-	var length int64
-	err = ReadLong(reader, &length)
+	var total int64
+	length, err := readItemCount(reader, &total)
 	if err != nil {
 		return
 	}
-	*values = make([]string, length)
+	*values = make([]string, 0, initialCapacity(length))
 	for length > 0 {
 		for i := 0; i < int(length); i++ {
 			var value string
@@ -158,9 +158,9 @@ func ReadStringSlice(reader Reader, values *[]string) (err error) {
 			if err != nil {
 				return
 			}
-			(*values)[i] = value
+			*values = append(*values, value)
 		}
-		err = ReadLong(reader, &length)
+		length, err = readItemCount(reader, &total)
 		if err != nil {
 			return err
 		}
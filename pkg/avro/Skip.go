@@ -0,0 +1,183 @@
+package avro
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avroschema"
+)
+
+// Skip reads past a value of schema without decoding it. Array and map blocks
+// written with their size are skipped whole, bytes, strings and fixed values
+// are skipped without being copied. Nesting is limited by the MaxDepth of
+// reader, or of DefaultDecoderConfig if reader is not a Decoder.
+func Skip(reader Reader, schema avroschema.Schema) (err error) {
+	_, ok := reader.(*Decoder)
+	if !ok {
+		reader = NewDecoder(reader, DefaultDecoderConfig)
+	}
+	return skip(reader, schema)
+}
+
+func skip(reader Reader, schema avroschema.Schema) (err error) {
+	switch schema := schema.(type) {
+	case *avroschema.Record:
+		return ReadNested(reader, func() error {
+			for _, field := range schema.Fields {
+				err := skip(reader, field.Type)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	case *avroschema.Enum:
+		var index int64
+		return ReadLong(reader, &index)
+	case *avroschema.Fixed:
+		return discard(reader, int64(schema.Size))
+	case *avroschema.Array:
+		return skipCollection(reader, func() error {
+			return skip(reader, schema.Items)
+		})
+	case *avroschema.Map:
+		return skipCollection(reader, func() error {
+			err := skipBytes(reader)
+			if err != nil {
+				return err
+			}
+			return skip(reader, schema.Values)
+		})
+	case avroschema.Union:
+		var index int64
+		err = ReadLong(reader, &index)
+		if err != nil {
+			return
+		}
+		if index < 0 || index >= int64(len(schema)) {
+			return fmt.Errorf("union index %d out of range", index)
+		}
+		return ReadNested(reader, func() error {
+			return skip(reader, schema[index])
+		})
+	case *avroschema.Reference:
+		if schema.Schema == nil {
+			return fmt.Errorf("cannot skip unresolved reference to %s", schema.Name)
+		}
+		return skip(reader, schema.Schema)
+	case nil:
+		return errors.New("cannot skip nil schema")
+	}
+	switch schema.GetType() {
+	case avroschema.AvroTypeNull:
+		return
+	case avroschema.AvroTypeBoolean:
+		_, err = reader.ReadByte()
+		return
+	case avroschema.AvroTypeInt, avroschema.AvroTypeLong:
+		var value int64
+		return ReadLong(reader, &value)
+	case avroschema.AvroTypeFloat:
+		return discard(reader, 4)
+	case avroschema.AvroTypeDouble:
+		return discard(reader, 8)
+	case avroschema.AvroTypeBytes, avroschema.AvroTypeString:
+		return skipBytes(reader)
+	default:
+		return fmt.Errorf("cannot skip schema of type %s", schema.GetType())
+	}
+}
+
+// ReadRecordProjection reads a record of schema, calling the function in
+// readField for each field named there and skipping the others.
+func ReadRecordProjection(reader Reader, schema *avroschema.Record, readField map[string]func(reader Reader) error) (err error) {
+	for _, field := range schema.Fields {
+		read, ok := readField[field.Name]
+		if ok {
+			err = read(reader)
+		} else {
+			err = Skip(reader, field.Type)
+		}
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func skipCollection(reader Reader, skipItem func() error) error {
+	return ReadNested(reader, func() error {
+		for {
+			count, size, err := ReadCollectionBlockHeader(reader)
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				return nil
+			}
+			if size >= 0 {
+				err = discard(reader, size)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			for i := int64(0); i < count; i++ {
+				err = skipItem()
+				if err != nil {
+					return err
+				}
+			}
+		}
+	})
+}
+
+func skipBytes(reader Reader) (err error) {
+	var length int64
+	err = ReadLong(reader, &length)
+	if err != nil {
+		return
+	}
+	if length < 0 {
+		return ErrNegativeLength
+	}
+	return discard(reader, length)
+}
+
+// discard reads past n bytes, seeking or discarding buffered bytes where the
+// reader allows it.
+func discard(reader io.Reader, n int64) (err error) {
+	switch reader := reader.(type) {
+	case *Decoder:
+		return discard(reader.reader, n)
+	case *bufio.Reader:
+		if int64(int(n)) != n {
+			break
+		}
+		_, err = reader.Discard(int(n))
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return
+	case lenSeeker:
+		if n > int64(reader.Len()) {
+			return io.ErrUnexpectedEOF
+		}
+		_, err = reader.Seek(n, io.SeekCurrent)
+		return
+	}
+	_, err = io.CopyN(ioutil.Discard, reader, n)
+	if errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	return
+}
+
+// lenSeeker is implemented by bytes.Reader and strings.Reader.
+type lenSeeker interface {
+	io.Seeker
+	Len() int
+}
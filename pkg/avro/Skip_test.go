@@ -0,0 +1,163 @@
+package avro_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avro"
+	"github.com/Ryan-A-B/avro-go/pkg/avroschema"
+)
+
+func TestSkip(t *testing.T) {
+	Convey("TestSkip", t, func() {
+		schema, err := avroschema.ParseSchema([]byte(`{
+			"type": "record",
+			"name": "Wide",
+			"fields": [
+				{"name": "null", "type": "null"},
+				{"name": "boolean", "type": "boolean"},
+				{"name": "int", "type": "int"},
+				{"name": "long", "type": {"type": "long"}},
+				{"name": "float", "type": "float"},
+				{"name": "double", "type": "double"},
+				{"name": "bytes", "type": "bytes"},
+				{"name": "fixed", "type": {"type": "fixed", "name": "MD5", "size": 16}},
+				{"name": "enum", "type": {"type": "enum", "name": "Suit", "symbols": ["HEARTS", "SPADES"]}},
+				{"name": "array", "type": {"type": "array", "items": "long"}},
+				{"name": "sized", "type": {"type": "array", "items": "string"}},
+				{"name": "map", "type": {"type": "map", "values": "string"}},
+				{"name": "union", "type": ["null", "string"]},
+				{"name": "person", "type": {
+					"type": "record",
+					"name": "Person",
+					"fields": [
+						{"name": "name", "type": "string"},
+						{"name": "age", "type": "int"}
+					]
+				}},
+				{"name": "string", "type": "string"}
+			]
+		}`))
+		So(err, ShouldBeNil)
+		var buffer bytes.Buffer
+		writer := bufio.NewWriter(&buffer)
+		person := generatePeople(1)[0]
+		write := func(n int, err error) {
+			So(err, ShouldBeNil)
+		}
+		write(0, avro.WriteBoolean(writer, true))
+		write(avro.WriteInt(writer, -12))
+		write(avro.WriteLong(writer, 1<<40))
+		write(avro.WriteFloat(writer, 1.5))
+		write(avro.WriteDouble(writer, 2.5))
+		write(avro.WriteBytes(writer, []byte("bytes")))
+		write(writer.Write(make([]byte, 16)))
+		write(avro.WriteLong(writer, 1))
+		write(0, avro.WriteLongArray(writer, []int64{1, 2, 3}))
+		write(avro.WriteArrayTo(writer, 2, func(writer avro.Writer, i int) (int, error) {
+			return avro.WriteString(writer, "sized")
+		}, avro.WithSizedBlocks()))
+		write(avro.WriteStringMap(writer, map[string]string{"x": "y"}))
+		write(avro.WriteLong(writer, 1))
+		write(avro.WriteString(writer, "union"))
+		write(person.WriteAvro(writer))
+		write(avro.WriteString(writer, "last"))
+		So(writer.Flush(), ShouldBeNil)
+		data := buffer.Bytes()
+		Convey("whole record", func() {
+			readers := map[string]avro.Reader{
+				"bytes.Reader": bytes.NewReader(data),
+				"bufio.Reader": bufio.NewReader(bytes.NewReader(data)),
+				"Decoder":      avro.NewDecoder(bytes.NewReader(data), avro.DefaultDecoderConfig),
+				"ObjectBlock":  blockOf(data),
+			}
+			for _, reader := range readers {
+				err = avro.Skip(reader, schema)
+				So(err, ShouldBeNil)
+				_, err = reader.ReadByte()
+				So(err, ShouldNotBeNil)
+			}
+		})
+		Convey("projection", func() {
+			var actualPerson Person
+			var last string
+			err = avro.ReadRecordProjection(bytes.NewReader(data), schema.(*avroschema.Record), map[string]func(reader avro.Reader) error{
+				"person": func(reader avro.Reader) error {
+					return actualPerson.ReadAvro(reader)
+				},
+				"string": func(reader avro.Reader) (err error) {
+					last, err = avro.ReadString(reader)
+					return
+				},
+			})
+			So(err, ShouldBeNil)
+			So(actualPerson, ShouldResemble, person)
+			So(last, ShouldEqual, "last")
+		})
+		Convey("truncated", func() {
+			err = avro.Skip(bytes.NewReader(data[:len(data)-2]), schema)
+			So(err, ShouldNotBeNil)
+		})
+		Convey("unresolved reference", func() {
+			err := avro.Skip(bytes.NewReader([]byte{0}), &avroschema.Reference{Name: "Missing"})
+			So(err, ShouldNotBeNil)
+			err = avro.Skip(bytes.NewReader([]byte{0}), nil)
+			So(err, ShouldNotBeNil)
+		})
+		Convey("recursive", func() {
+			schema, err := avroschema.ParseSchema([]byte(`{
+				"type": "record",
+				"name": "LongList",
+				"fields": [
+					{"name": "value", "type": "long"},
+					{"name": "next", "type": ["null", "LongList"]}
+				]
+			}`))
+			So(err, ShouldBeNil)
+			var buffer bytes.Buffer
+			for _, value := range []int64{1, 2, 3} {
+				_, err = avro.WriteLong(&buffer, value)
+				So(err, ShouldBeNil)
+				_, err = avro.WriteLong(&buffer, 1)
+				So(err, ShouldBeNil)
+			}
+			_, err = avro.WriteLong(&buffer, 4)
+			So(err, ShouldBeNil)
+			_, err = avro.WriteLong(&buffer, 0)
+			So(err, ShouldBeNil)
+			data := append([]byte(nil), buffer.Bytes()...)
+			err = avro.Skip(&buffer, schema)
+			So(err, ShouldBeNil)
+			So(buffer.Len(), ShouldEqual, 0)
+
+			var limitErr *avro.LimitExceededError
+			err = avro.Skip(avro.NewDecoder(bytes.NewReader(data), avro.DecoderConfig{MaxDepth: 4}), schema)
+			So(errors.As(err, &limitErr), ShouldBeTrue)
+			So(limitErr.Limit, ShouldEqual, "MaxDepth")
+
+			Convey("deeper than the default depth", func() {
+				var deep bytes.Buffer
+				for i := 0; i < 10000; i++ {
+					_, err = avro.WriteLong(&deep, int64(i))
+					So(err, ShouldBeNil)
+					_, err = avro.WriteLong(&deep, 1)
+					So(err, ShouldBeNil)
+				}
+				err = avro.Skip(bytes.NewReader(deep.Bytes()), schema)
+				So(errors.As(err, &limitErr), ShouldBeTrue)
+				So(limitErr.Max, ShouldEqual, avro.DefaultDecoderConfig.MaxDepth)
+			})
+		})
+	})
+}
+
+func blockOf(data []byte) *avro.ObjectBlock {
+	block := new(avro.ObjectBlock)
+	_, err := block.Write(data)
+	So(err, ShouldBeNil)
+	return block
+}
@@ -0,0 +1,72 @@
+package avro
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+
+	"github.com/golang/snappy"
+)
+
+// snappyFramedMagic is the stream identifier that starts the snappy framing
+// format, which earlier versions of this package wrote instead of the raw
+// snappy blocks required by the specification.
+var snappyFramedMagic = []byte("\xff\x06\x00\x00sNaPpY")
+
+// snappyCodec compresses blocks as a single raw snappy block followed by the
+// big-endian CRC32 of the uncompressed data.
+type snappyCodec struct{}
+
+func (snappyCodec) Name() CompressionCodec {
+	return CompressionCodecSnappy
+}
+
+func (snappyCodec) Compress(dst, src []byte) ([]byte, error) {
+	dst = append(dst, snappy.Encode(nil, src)...)
+	var checksum [4]byte
+	binary.BigEndian.PutUint32(checksum[:], crc32.ChecksumIEEE(src))
+	return append(dst, checksum[:]...), nil
+}
+
+func (codec snappyCodec) Decompress(dst, src []byte) ([]byte, error) {
+	return codec.decompressLimit(dst, src, 0)
+}
+
+func (snappyCodec) decompressLimit(dst, src []byte, limit int64) ([]byte, error) {
+	if len(src) < 4 {
+		return nil, fmt.Errorf("corrupt snappy block: %d bytes is too short to contain a checksum", len(src))
+	}
+	// the decoded length is read from the block before anything is allocated
+	length, err := snappy.DecodedLen(src[:len(src)-4])
+	if err != nil {
+		return nil, fmt.Errorf("corrupt snappy block: %v", err)
+	}
+	if limit > 0 && int64(length) > limit {
+		return nil, decompressedBlockSizeExceeded(int64(length), limit)
+	}
+	data, err := snappy.Decode(nil, src[:len(src)-4])
+	if err != nil {
+		return nil, fmt.Errorf("corrupt snappy block: %v", err)
+	}
+	expected := binary.BigEndian.Uint32(src[len(src)-4:])
+	actual := crc32.ChecksumIEEE(data)
+	if actual != expected {
+		return nil, fmt.Errorf("corrupt snappy block: checksum mismatch, expected %08x got %08x", expected, actual)
+	}
+	return append(dst, data...), nil
+}
+
+// DecompressLegacy also accepts the snappy framing format written by earlier
+// versions of this package.
+func (codec snappyCodec) DecompressLegacy(dst, src []byte) ([]byte, error) {
+	return codec.decompressLegacyLimit(dst, src, 0)
+}
+
+func (codec snappyCodec) decompressLegacyLimit(dst, src []byte, limit int64) ([]byte, error) {
+	if !bytes.HasPrefix(src, snappyFramedMagic) {
+		return codec.decompressLimit(dst, src, limit)
+	}
+	return readAllInto(dst, ioutil.NopCloser(snappy.NewReader(bytes.NewReader(src))), limit)
+}
@@ -0,0 +1,64 @@
+package avro_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io/ioutil"
+	"testing"
+
+	"github.com/golang/snappy"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avro"
+)
+
+func TestSnappyCodec(t *testing.T) {
+	Convey("TestSnappyCodec", t, func() {
+		data := []byte(generateRandomString(1024))
+		var compressed bytes.Buffer
+		codecWriter, err := avro.NewCodecWriter(&compressed, avro.CompressionCodecSnappy)
+		So(err, ShouldBeNil)
+		_, err = codecWriter.Write(data)
+		So(err, ShouldBeNil)
+		err = codecWriter.Close()
+		So(err, ShouldBeNil)
+		Convey("raw block with checksum", func() {
+			block := compressed.Bytes()
+			encoded := block[:len(block)-4]
+			decoded, err := snappy.Decode(nil, encoded)
+			So(err, ShouldBeNil)
+			So(decoded, ShouldResemble, data)
+			So(binary.BigEndian.Uint32(block[len(block)-4:]), ShouldEqual, crc32.ChecksumIEEE(data))
+		})
+		Convey("round trip", func() {
+			codecReader, err := avro.NewCodecReader(&compressed, avro.CompressionCodecSnappy)
+			So(err, ShouldBeNil)
+			decoded, err := ioutil.ReadAll(codecReader)
+			So(err, ShouldBeNil)
+			So(decoded, ShouldResemble, data)
+		})
+		Convey("checksum mismatch", func() {
+			block := compressed.Bytes()
+			block[len(block)-1]++
+			_, err := avro.NewCodecReader(&compressed, avro.CompressionCodecSnappy)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "checksum mismatch")
+		})
+		Convey("framed requires legacy", func() {
+			var framed bytes.Buffer
+			framedWriter := snappy.NewBufferedWriter(&framed)
+			_, err := framedWriter.Write(data)
+			So(err, ShouldBeNil)
+			err = framedWriter.Close()
+			So(err, ShouldBeNil)
+			_, err = avro.NewCodecReader(bytes.NewReader(framed.Bytes()), avro.CompressionCodecSnappy)
+			So(err, ShouldNotBeNil)
+			codecReader, err := avro.NewLegacyCodecReader(bytes.NewReader(framed.Bytes()), avro.CompressionCodecSnappy)
+			So(err, ShouldBeNil)
+			decoded, err := ioutil.ReadAll(codecReader)
+			So(err, ShouldBeNil)
+			So(decoded, ShouldResemble, data)
+		})
+	})
+}
@@ -2,23 +2,30 @@ package avro
 
 type WriteItemFunc func(i int) (int, error)
 
+// WriteItemToFunc writes item i to writer.
+type WriteItemToFunc func(writer Writer, i int) (int, error)
+
+// WriteArray writes length items with writeItem as a single block. Use
+// WriteArrayTo for options such as WithSizedBlocks.
 func WriteArray(writer Writer, length int, writeItem WriteItemFunc) (int, error) {
-	var nTotal int
-	var err error
-	var n int
-	n, err = WriteLong(writer, int64(length))
-	nTotal += n
-	if err != nil {
-		return nTotal, err
-	}
-	for i := 0; i < length; i++ {
-		n, err = writeItem(i)
-		nTotal += n
-		if err != nil {
-			return nTotal, err
+	return WriteArrayTo(writer, length, func(_ Writer, i int) (int, error) {
+		return writeItem(i)
+	})
+}
+
+// WriteArrayTo is WriteArray for items written to the Writer they are given
+// rather than one captured by writeItem, which allows options such as
+// WithSizedBlocks to buffer them.
+func WriteArrayTo(writer Writer, length int, writeItem WriteItemToFunc, options ...CollectionOption) (int, error) {
+	return writeCollection(writer, length, options, func(writer Writer) (nTotal int, err error) {
+		var n int
+		for i := 0; i < length; i++ {
+			n, err = writeItem(writer, i)
+			nTotal += n
+			if err != nil {
+				return
+			}
 		}
-	}
-	n, err = WriteLong(writer, 0)
-	nTotal += n
-	return nTotal, err
+		return
+	})
 }
@@ -0,0 +1,158 @@
+package avro
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"time"
+)
+
+// WriteDate writes the date of value in its location as the number of days
+// since the unix epoch.
+func WriteDate(writer io.Writer, value time.Time) (n int, err error) {
+	year, month, day := value.Date()
+	days := time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / secondsPerDay
+	if days < math.MinInt32 || days > math.MaxInt32 {
+		err = fmt.Errorf("%w: date %s", ErrValueOutOfRange, value)
+		return
+	}
+	return WriteInt(writer, int32(days))
+}
+
+// WriteTimeMillis writes value, the time since midnight, as a time-millis.
+func WriteTimeMillis(writer io.Writer, value time.Duration) (n int, err error) {
+	millis, err := timeOfDayUnits(value, time.Millisecond)
+	if err != nil {
+		return
+	}
+	return WriteInt(writer, int32(millis))
+}
+
+// WriteTimeMicros writes value, the time since midnight, as a time-micros.
+func WriteTimeMicros(writer io.Writer, value time.Duration) (n int, err error) {
+	micros, err := timeOfDayUnits(value, time.Microsecond)
+	if err != nil {
+		return
+	}
+	return WriteLong(writer, micros)
+}
+
+func WriteTimestampMillis(writer io.Writer, value time.Time) (n int, err error) {
+	return writeTimestamp(writer, value, time.Millisecond)
+}
+
+func WriteTimestampMicros(writer io.Writer, value time.Time) (n int, err error) {
+	return writeTimestamp(writer, value, time.Microsecond)
+}
+
+// WriteTimestampNanos writes value as a timestamp-nanos, which can only
+// represent times between the years 1677 and 2262.
+func WriteTimestampNanos(writer io.Writer, value time.Time) (n int, err error) {
+	return writeTimestamp(writer, value, time.Nanosecond)
+}
+
+// WriteLocalTimestampMillis writes the date and clock of value in its
+// location as a local-timestamp-millis.
+func WriteLocalTimestampMillis(writer io.Writer, value time.Time) (n int, err error) {
+	return writeTimestamp(writer, wallClock(value), time.Millisecond)
+}
+
+// WriteLocalTimestampMicros writes the date and clock of value in its
+// location as a local-timestamp-micros.
+func WriteLocalTimestampMicros(writer io.Writer, value time.Time) (n int, err error) {
+	return writeTimestamp(writer, wallClock(value), time.Microsecond)
+}
+
+// WriteLocalTimestampNanos writes the date and clock of value in its
+// location as a local-timestamp-nanos.
+func WriteLocalTimestampNanos(writer io.Writer, value time.Time) (n int, err error) {
+	return writeTimestamp(writer, wallClock(value), time.Nanosecond)
+}
+
+// writeTimestamp writes value as a number of units since the unix epoch,
+// truncating anything finer than unit.
+func writeTimestamp(writer io.Writer, value time.Time, unit time.Duration) (n int, err error) {
+	units, err := unixUnits(value, unit)
+	if err != nil {
+		return
+	}
+	return WriteLong(writer, units)
+}
+
+// WriteDecimal writes value as a decimal on bytes. value must have at most
+// scale decimal places and precision digits.
+func WriteDecimal(writer io.Writer, value *big.Rat, precision int, scale int) (n int, err error) {
+	unscaled, err := unscaledDecimal(value, precision, scale)
+	if err != nil {
+		return
+	}
+	return WriteUnscaledDecimal(writer, unscaled, precision)
+}
+
+// WriteFixedDecimal writes value as a decimal on a fixed of size bytes.
+// value must have at most scale decimal places and precision digits.
+func WriteFixedDecimal(writer io.Writer, value *big.Rat, size int, precision int, scale int) (n int, err error) {
+	unscaled, err := unscaledDecimal(value, precision, scale)
+	if err != nil {
+		return
+	}
+	return WriteUnscaledFixedDecimal(writer, unscaled, size, precision)
+}
+
+// WriteUnscaledDecimal writes the unscaled integer value of a decimal on
+// bytes, which must have at most precision digits.
+func WriteUnscaledDecimal(writer io.Writer, value *big.Int, precision int) (n int, err error) {
+	err = checkPrecision(value, precision)
+	if err != nil {
+		return
+	}
+	data, err := encodeTwosComplement(value, 0)
+	if err != nil {
+		return
+	}
+	return WriteBytes(writer, data)
+}
+
+// WriteUnscaledFixedDecimal writes the unscaled integer value of a decimal on
+// a fixed of size bytes, which must have at most precision digits.
+func WriteUnscaledFixedDecimal(writer io.Writer, value *big.Int, size int, precision int) (n int, err error) {
+	err = checkPrecision(value, precision)
+	if err != nil {
+		return
+	}
+	data, err := encodeTwosComplement(value, size)
+	if err != nil {
+		return
+	}
+	return writer.Write(data)
+}
+
+// WriteUUID writes value, in the 36 character hyphenated form, as a uuid on
+// a string.
+func WriteUUID(writer io.Writer, value string) (n int, err error) {
+	_, err = parseUUID(value)
+	if err != nil {
+		return
+	}
+	return WriteString(writer, value)
+}
+
+// WriteFixedUUID writes value, in the 36 character hyphenated form, as a
+// uuid on a fixed of 16 bytes.
+func WriteFixedUUID(writer io.Writer, value string) (n int, err error) {
+	uuid, err := parseUUID(value)
+	if err != nil {
+		return
+	}
+	return writer.Write(uuid[:])
+}
+
+func WriteDuration(writer io.Writer, value Duration) (n int, err error) {
+	var data [durationSize]byte
+	binary.LittleEndian.PutUint32(data[0:4], value.Months)
+	binary.LittleEndian.PutUint32(data[4:8], value.Days)
+	binary.LittleEndian.PutUint32(data[8:12], value.Milliseconds)
+	return writer.Write(data[:])
+}
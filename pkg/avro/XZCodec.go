@@ -0,0 +1,44 @@
+package avro
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	"github.com/ulikunitz/xz"
+)
+
+// xzCodec compresses each block as an xz stream.
+type xzCodec struct{}
+
+func (xzCodec) Name() CompressionCodec {
+	return CompressionCodecXZ
+}
+
+func (xzCodec) Compress(dst, src []byte) ([]byte, error) {
+	buffer := bytes.NewBuffer(dst)
+	writer, err := xz.NewWriter(buffer)
+	if err != nil {
+		return nil, err
+	}
+	_, err = writer.Write(src)
+	if err != nil {
+		return nil, err
+	}
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (codec xzCodec) Decompress(dst, src []byte) ([]byte, error) {
+	return codec.decompressLimit(dst, src, 0)
+}
+
+func (xzCodec) decompressLimit(dst, src []byte, limit int64) ([]byte, error) {
+	reader, err := xz.NewReader(bytes.NewReader(src))
+	if err != nil {
+		return nil, err
+	}
+	return readAllInto(dst, ioutil.NopCloser(reader), limit)
+}
@@ -0,0 +1,100 @@
+package avro
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const defaultZstandardLevel = 3
+
+// zstandardCodec compresses each block as a single zstandard frame. The
+// encoder and decoders are created on first use as they allocate sizeable
+// buffers. There is a decoder for each decompression limit as the limit is
+// fixed when a decoder is created.
+type zstandardCodec struct {
+	level         int
+	encoderOnce   sync.Once
+	encoder       *zstd.Encoder
+	err           error
+	decodersMutex sync.Mutex
+	decoders      map[int64]*zstd.Decoder
+}
+
+// NewZstandardCodec returns a zstandard codec compressing at level, which is
+// interpreted as a zstd command line level between 1 and 22.
+func NewZstandardCodec(level int) (Codec, error) {
+	if level < 1 || level > 22 {
+		return nil, fmt.Errorf("invalid zstandard compression level %d", level)
+	}
+	return &zstandardCodec{level: level}, nil
+}
+
+func (codec *zstandardCodec) Name() CompressionCodec {
+	return CompressionCodecZstandard
+}
+
+func (codec *zstandardCodec) WithLevel(level int) (Codec, error) {
+	return NewZstandardCodec(level)
+}
+
+func (codec *zstandardCodec) Compress(dst, src []byte) ([]byte, error) {
+	codec.encoderOnce.Do(func() {
+		level := zstd.EncoderLevelFromZstd(codec.level)
+		codec.encoder, codec.err = zstd.NewWriter(nil, zstd.WithEncoderLevel(level))
+	})
+	if codec.encoder == nil {
+		return nil, codec.err
+	}
+	return codec.encoder.EncodeAll(src, dst), nil
+}
+
+func (codec *zstandardCodec) Decompress(dst, src []byte) ([]byte, error) {
+	return codec.decompressLimit(dst, src, 0)
+}
+
+func (codec *zstandardCodec) decompressLimit(dst, src []byte, limit int64) ([]byte, error) {
+	decoder, err := codec.decoder(limit)
+	if err != nil {
+		return nil, err
+	}
+	offset := len(dst)
+	dst, err = decoder.DecodeAll(src, dst)
+	if limit > 0 && (errors.Is(err, zstd.ErrDecoderSizeExceeded) || errors.Is(err, zstd.ErrWindowSizeExceeded)) {
+		// the decoder stops before the whole size is known, and rejects
+		// frames whose window is larger than the limit
+		return nil, decompressedBlockSizeExceeded(limit+1, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+	size := int64(len(dst) - offset)
+	if limit > 0 && size > limit {
+		return nil, decompressedBlockSizeExceeded(size, limit)
+	}
+	return dst, nil
+}
+
+func (codec *zstandardCodec) decoder(limit int64) (*zstd.Decoder, error) {
+	codec.decodersMutex.Lock()
+	defer codec.decodersMutex.Unlock()
+	decoder, ok := codec.decoders[limit]
+	if ok {
+		return decoder, nil
+	}
+	var options []zstd.DOption
+	if limit > 0 {
+		options = append(options, zstd.WithDecoderMaxMemory(uint64(limit)))
+	}
+	decoder, err := zstd.NewReader(nil, options...)
+	if err != nil {
+		return nil, err
+	}
+	if codec.decoders == nil {
+		codec.decoders = make(map[int64]*zstd.Decoder)
+	}
+	codec.decoders[limit] = decoder
+	return decoder, nil
+}
@@ -12,3 +12,36 @@ type Writer interface {
 	io.Writer
 	io.ByteWriter
 }
+
+// countingReader tracks the number of bytes read through it so callers can
+// recover file offsets from behind a buffered reader.
+type countingReader struct {
+	reader Reader
+	offset int64
+}
+
+func (countingReader *countingReader) Read(p []byte) (n int, err error) {
+	n, err = countingReader.reader.Read(p)
+	countingReader.offset += int64(n)
+	return
+}
+
+func (countingReader *countingReader) ReadByte() (c byte, err error) {
+	c, err = countingReader.reader.ReadByte()
+	if err == nil {
+		countingReader.offset++
+	}
+	return
+}
+
+// countingWriter tracks the number of bytes written through it.
+type countingWriter struct {
+	writer io.Writer
+	offset int64
+}
+
+func (countingWriter *countingWriter) Write(p []byte) (n int, err error) {
+	n, err = countingWriter.writer.Write(p)
+	countingWriter.offset += int64(n)
+	return
+}
@@ -24,3 +24,14 @@ const (
 func (avroType AvroType) GetType() AvroType {
 	return avroType
 }
+
+// Validate checks that avroType is a primitive type, the complex types are
+// only valid as their own schema objects.
+func (avroType AvroType) Validate() error {
+	if primitiveTypes[avroType] {
+		return nil
+	}
+	var errs ValidationErrors
+	errs.add("", "unknown type %q", avroType)
+	return errs
+}
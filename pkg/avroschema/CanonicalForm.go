@@ -0,0 +1,110 @@
+package avroschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+)
+
+// CanonicalForm returns the Parsing Canonical Form of schema: full names, only
+// the attributes relevant to reading data in a fixed order, and no
+// whitespace. Schemas with the same canonical form read data identically.
+func CanonicalForm(schema Schema) []byte {
+	writer := canonicalWriter{
+		named: make(map[string]bool),
+	}
+	writer.writeSchema(schema)
+	return writer.buffer.Bytes()
+}
+
+type canonicalWriter struct {
+	buffer bytes.Buffer
+	// named holds the full names of the named types already written, later
+	// uses are written as the name alone
+	named map[string]bool
+}
+
+func (writer *canonicalWriter) writeSchema(schema Schema) {
+	switch schema := schema.(type) {
+	case *Reference:
+		writer.writeString(schema.Name)
+	case *Record:
+		if writer.writeName(schema.NamedType) {
+			return
+		}
+		writer.buffer.WriteString(`,"type":"record","fields":[`)
+		for i, field := range schema.Fields {
+			if i > 0 {
+				writer.buffer.WriteByte(',')
+			}
+			writer.buffer.WriteString(`{"name":`)
+			writer.writeString(field.Name)
+			writer.buffer.WriteString(`,"type":`)
+			writer.writeSchema(field.Type)
+			writer.buffer.WriteByte('}')
+		}
+		writer.buffer.WriteString("]}")
+	case *Enum:
+		if writer.writeName(schema.NamedType) {
+			return
+		}
+		writer.buffer.WriteString(`,"type":"enum","symbols":[`)
+		for i, symbol := range schema.Symbols {
+			if i > 0 {
+				writer.buffer.WriteByte(',')
+			}
+			writer.writeString(symbol)
+		}
+		writer.buffer.WriteString("]}")
+	case *Fixed:
+		if writer.writeName(schema.NamedType) {
+			return
+		}
+		writer.buffer.WriteString(`,"type":"fixed","size":`)
+		writer.buffer.WriteString(strconv.Itoa(schema.Size))
+		writer.buffer.WriteByte('}')
+	case *Array:
+		writer.buffer.WriteString(`{"type":"array","items":`)
+		writer.writeSchema(schema.Items)
+		writer.buffer.WriteByte('}')
+	case *Map:
+		writer.buffer.WriteString(`{"type":"map","values":`)
+		writer.writeSchema(schema.Values)
+		writer.buffer.WriteByte('}')
+	case Union:
+		writer.buffer.WriteByte('[')
+		for i, item := range schema {
+			if i > 0 {
+				writer.buffer.WriteByte(',')
+			}
+			writer.writeSchema(item)
+		}
+		writer.buffer.WriteByte(']')
+	default:
+		writer.writeString(string(schema.GetType()))
+	}
+}
+
+// writeName writes the opening of a named type up to its name, or only its
+// full name if it has already been written, in which case it returns true.
+func (writer *canonicalWriter) writeName(namedType NamedType) (written bool) {
+	fullName := namedType.FullName()
+	if writer.named[fullName] {
+		writer.writeString(fullName)
+		return true
+	}
+	writer.named[fullName] = true
+	writer.buffer.WriteString(`{"name":`)
+	writer.writeString(fullName)
+	return false
+}
+
+// writeString writes value as a JSON string with non-ASCII characters written
+// as UTF-8 rather than escaped.
+func (writer *canonicalWriter) writeString(value string) {
+	encoder := json.NewEncoder(&writer.buffer)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(value)
+	// Encode terminates each value with a newline
+	writer.buffer.Truncate(writer.buffer.Len() - 1)
+}
@@ -0,0 +1,69 @@
+package avroschema_test
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avroschema"
+)
+
+func TestCanonicalForm(t *testing.T) {
+	Convey("TestCanonicalForm", t, func() {
+		canonicalForm := func(data string) string {
+			schema, err := avroschema.ParseSchema([]byte(data))
+			So(err, ShouldBeNil)
+			return string(avroschema.CanonicalForm(schema))
+		}
+		Convey("primitive", func() {
+			So(canonicalForm(`"int"`), ShouldEqual, `"int"`)
+			So(canonicalForm(`{"type": "string"}`), ShouldEqual, `"string"`)
+			So(canonicalForm(`[ "null", {"type": "long"} ]`), ShouldEqual, `["null","long"]`)
+		})
+		Convey("collections", func() {
+			So(canonicalForm(`{"items": "bytes", "type": "array", "default": []}`), ShouldEqual, `{"type":"array","items":"bytes"}`)
+			So(canonicalForm(`{"values": {"type": "double"}, "type": "map"}`), ShouldEqual, `{"type":"map","values":"double"}`)
+		})
+		Convey("named types", func() {
+			actual := canonicalForm(`{
+				"type": "record",
+				"doc": "a customer",
+				"namespace": "com.acme",
+				"name": "Customer",
+				"aliases": ["Client"],
+				"fields": [
+					{"type": {"size": 16, "type": "fixed", "name": "Id"}, "name": "id", "doc": "the id"},
+					{"name": "tier", "type": {"type": "enum", "name": "org.Tier", "symbols": ["GOLD", "SILVER"], "default": "SILVER"}},
+					{"name": "previous", "type": ["null", "Id"], "default": null},
+					{"name": "referrer", "type": ["null", "com.acme.Customer"]}
+				]
+			}`)
+			So(actual, ShouldEqual, `{"name":"com.acme.Customer","type":"record","fields":[`+
+				`{"name":"id","type":{"name":"com.acme.Id","type":"fixed","size":16}},`+
+				`{"name":"tier","type":{"name":"org.Tier","type":"enum","symbols":["GOLD","SILVER"]}},`+
+				`{"name":"previous","type":["null","com.acme.Id"]},`+
+				`{"name":"referrer","type":["null","com.acme.Customer"]}]}`)
+		})
+		Convey("same form", func() {
+			expected := canonicalForm(`{"type": "record", "name": "a.R", "fields": [{"name": "f", "type": "int"}]}`)
+			So(canonicalForm(`{
+				"fields": [{"type": {"type": "int"}, "name": "f", "default": 1}],
+				"name": "R",
+				"namespace": "a",
+				"type": "record"
+			}`), ShouldEqual, expected)
+		})
+		Convey("constructed", func() {
+			fixed := &avroschema.Fixed{
+				SchemaBase: avroschema.SchemaBase{Type: avroschema.AvroTypeFixed},
+				NamedType:  avroschema.NamedType{Name: "x.MD5"},
+				Size:       16,
+			}
+			actual := avroschema.CanonicalForm(avroschema.Union{avroschema.AvroTypeNull, fixed, &avroschema.Array{
+				SchemaBase: avroschema.SchemaBase{Type: avroschema.AvroTypeArray},
+				Items:      fixed,
+			}})
+			So(string(actual), ShouldEqual, `["null",{"name":"x.MD5","type":"fixed","size":16},{"type":"array","items":"x.MD5"}]`)
+		})
+	})
+}
@@ -1,8 +1,32 @@
 package avroschema
 
+import "fmt"
+
 type Enum struct {
 	SchemaBase
 	NamedType
 	Symbols []string `json:"symbols"`
 	Default string   `json:"default,omitempty"`
 }
+
+func (enum *Enum) Validate() error {
+	var errs ValidationErrors
+	if enum.Type != AvroTypeEnum {
+		errs.add(".type", "expected type 'enum', got %s", enum.Type)
+	}
+	enum.NamedType.validate(&errs)
+	symbols := make(map[string]bool, len(enum.Symbols))
+	for i, symbol := range enum.Symbols {
+		path := fmt.Sprintf(".symbols[%d]", i)
+		if !isValidName(symbol) {
+			errs.add(path, "invalid symbol %q", symbol)
+		} else if symbols[symbol] {
+			errs.add(path, "duplicate symbol %q", symbol)
+		}
+		symbols[symbol] = true
+	}
+	if enum.Default != "" && !symbols[enum.Default] {
+		errs.add(".default", "default %q is not a symbol", enum.Default)
+	}
+	return errs.err()
+}
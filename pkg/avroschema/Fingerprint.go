@@ -0,0 +1,42 @@
+package avroschema
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+)
+
+// EmptyFingerprint64 is the CRC-64-AVRO fingerprint of no bytes.
+const EmptyFingerprint64 uint64 = 0xc15d213aa4d7a795
+
+var fingerprint64Table = newFingerprint64Table()
+
+func newFingerprint64Table() (table [256]uint64) {
+	for i := range table {
+		fingerprint := uint64(i)
+		for j := 0; j < 8; j++ {
+			fingerprint = (fingerprint >> 1) ^ (EmptyFingerprint64 & -(fingerprint & 1))
+		}
+		table[i] = fingerprint
+	}
+	return
+}
+
+// Fingerprint64 is the 64-bit Rabin fingerprint (CRC-64-AVRO) of the
+// canonical form of schema, as used by single-object encoding.
+func Fingerprint64(schema Schema) uint64 {
+	fingerprint := EmptyFingerprint64
+	for _, b := range CanonicalForm(schema) {
+		fingerprint = (fingerprint >> 8) ^ fingerprint64Table[byte(fingerprint)^b]
+	}
+	return fingerprint
+}
+
+// FingerprintMD5 is the MD5 hash of the canonical form of schema.
+func FingerprintMD5(schema Schema) [md5.Size]byte {
+	return md5.Sum(CanonicalForm(schema))
+}
+
+// FingerprintSHA256 is the SHA-256 hash of the canonical form of schema.
+func FingerprintSHA256(schema Schema) [sha256.Size]byte {
+	return sha256.Sum256(CanonicalForm(schema))
+}
@@ -0,0 +1,43 @@
+package avroschema_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avroschema"
+)
+
+func TestFingerprint(t *testing.T) {
+	Convey("TestFingerprint", t, func() {
+		parse := func(data string) avroschema.Schema {
+			schema, err := avroschema.ParseSchema([]byte(data))
+			So(err, ShouldBeNil)
+			return schema
+		}
+		Convey("Fingerprint64", func() {
+			// reference values from the Avro specification, as signed longs
+			So(int64(avroschema.Fingerprint64(parse(`"null"`))), ShouldEqual, 7195948357588979594)
+			So(int64(avroschema.Fingerprint64(parse(`"int"`))), ShouldEqual, 8247732601305521295)
+			So(int64(avroschema.Fingerprint64(parse(`{"type": "string"}`))), ShouldEqual, -8142146995180207161)
+		})
+		Convey("canonical form", func() {
+			a := parse(`{"type": "record", "name": "a.R", "fields": [{"name": "f", "type": "int"}]}`)
+			b := parse(`{"type": "record", "name": "R", "namespace": "a", "doc": "R", "fields": [{"name": "f", "type": {"type": "int"}}]}`)
+			c := parse(`{"type": "record", "name": "a.R", "fields": [{"name": "f", "type": "long"}]}`)
+			So(avroschema.Fingerprint64(a), ShouldEqual, avroschema.Fingerprint64(b))
+			So(avroschema.Fingerprint64(a), ShouldNotEqual, avroschema.Fingerprint64(c))
+			md5 := avroschema.FingerprintMD5(b)
+			So(hex.EncodeToString(md5[:]), ShouldEqual, "bf0888e8c72fb3cc431df06ea735ebbb")
+			sha256 := avroschema.FingerprintSHA256(b)
+			So(hex.EncodeToString(sha256[:]), ShouldEqual, "24271d2b399f2c40135d799d72bcfdd9fc50b5139cd7d6a32f94e034b2bbd3d9")
+		})
+		Convey("primitive", func() {
+			md5 := avroschema.FingerprintMD5(avroschema.AvroTypeInt)
+			So(hex.EncodeToString(md5[:]), ShouldEqual, "ef524ea1b91e73173d938ade36c1db32")
+			sha256 := avroschema.FingerprintSHA256(avroschema.AvroTypeNull)
+			So(hex.EncodeToString(sha256[:]), ShouldEqual, "f072cbec3bf8841871d4284230c5e983dc211a56837aed862487148f947d1a1f")
+		})
+	})
+}
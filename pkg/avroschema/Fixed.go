@@ -5,3 +5,15 @@ type Fixed struct {
 	NamedType
 	Size int `json:"size"`
 }
+
+func (fixed *Fixed) Validate() error {
+	var errs ValidationErrors
+	if fixed.Type != AvroTypeFixed {
+		errs.add(".type", "expected type 'fixed', got %s", fixed.Type)
+	}
+	fixed.NamedType.validate(&errs)
+	if fixed.Size < 0 {
+		errs.add(".size", "negative size %d", fixed.Size)
+	}
+	return errs.err()
+}
@@ -0,0 +1,144 @@
+package avroschema
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// LogicalType annotates a primitive or fixed schema with how its values are
+// interpreted.
+type LogicalType string
+
+const (
+	LogicalTypeDecimal              LogicalType = "decimal"
+	LogicalTypeUUID                 LogicalType = "uuid"
+	LogicalTypeDate                 LogicalType = "date"
+	LogicalTypeTimeMillis           LogicalType = "time-millis"
+	LogicalTypeTimeMicros           LogicalType = "time-micros"
+	LogicalTypeTimestampMillis      LogicalType = "timestamp-millis"
+	LogicalTypeTimestampMicros      LogicalType = "timestamp-micros"
+	LogicalTypeTimestampNanos       LogicalType = "timestamp-nanos"
+	LogicalTypeLocalTimestampMillis LogicalType = "local-timestamp-millis"
+	LogicalTypeLocalTimestampMicros LogicalType = "local-timestamp-micros"
+	LogicalTypeLocalTimestampNanos  LogicalType = "local-timestamp-nanos"
+	LogicalTypeDuration             LogicalType = "duration"
+)
+
+// logicalTypeTypes are the types each logical type may annotate.
+var logicalTypeTypes = map[LogicalType][]AvroType{
+	LogicalTypeDecimal:              {AvroTypeBytes, AvroTypeFixed},
+	LogicalTypeUUID:                 {AvroTypeString, AvroTypeFixed},
+	LogicalTypeDate:                 {AvroTypeInt},
+	LogicalTypeTimeMillis:           {AvroTypeInt},
+	LogicalTypeTimeMicros:           {AvroTypeLong},
+	LogicalTypeTimestampMillis:      {AvroTypeLong},
+	LogicalTypeTimestampMicros:      {AvroTypeLong},
+	LogicalTypeTimestampNanos:       {AvroTypeLong},
+	LogicalTypeLocalTimestampMillis: {AvroTypeLong},
+	LogicalTypeLocalTimestampMicros: {AvroTypeLong},
+	LogicalTypeLocalTimestampNanos:  {AvroTypeLong},
+	LogicalTypeDuration:             {AvroTypeFixed},
+}
+
+// unmarshalSchema decodes data into value, which embeds schema, decoding the
+// logical type attributes leniently: a malformed attribute leaves a logical
+// type that resolveLogicalType drops rather than failing the parse.
+func unmarshalSchema(data []byte, value interface{}, schema *SchemaBase) (err error) {
+	var attributes map[string]json.RawMessage
+	err = json.Unmarshal(data, &attributes)
+	if err != nil {
+		return
+	}
+	logicalType := attributes["logicalType"]
+	precision := attributes["precision"]
+	scale := attributes["scale"]
+	if logicalType != nil || precision != nil || scale != nil {
+		delete(attributes, "logicalType")
+		delete(attributes, "precision")
+		delete(attributes, "scale")
+		data, err = json.Marshal(attributes)
+		if err != nil {
+			return
+		}
+	}
+	err = json.Unmarshal(data, value)
+	if err != nil {
+		return
+	}
+	var name string
+	if json.Unmarshal(logicalType, &name) != nil {
+		return
+	}
+	schema.LogicalType = LogicalType(name)
+	schema.Precision = lenientInt(precision)
+	schema.Scale = lenientInt(scale)
+	return
+}
+
+// lenientInt returns the JSON integer data, zero if it is absent or -1 if it
+// is not an integer.
+func lenientInt(data json.RawMessage) int {
+	if data == nil {
+		return 0
+	}
+	value, err := strconv.Atoi(string(data))
+	if err != nil {
+		return -1
+	}
+	return value
+}
+
+// resolveLogicalType drops a logical type that is unknown or invalid for the
+// schema, which is then read as its underlying type, as the specification
+// requires. size is the size of a fixed schema.
+func (schema *SchemaBase) resolveLogicalType(size int) {
+	if schema.LogicalType != LogicalTypeDecimal {
+		schema.Precision = 0
+		schema.Scale = 0
+	}
+	if !schema.isValidLogicalType(size) {
+		schema.LogicalType = ""
+		schema.Precision = 0
+		schema.Scale = 0
+	}
+}
+
+func (schema *SchemaBase) isValidLogicalType(size int) bool {
+	if schema.LogicalType == "" {
+		return true
+	}
+	if !containsType(logicalTypeTypes[schema.LogicalType], schema.Type) {
+		return false
+	}
+	switch schema.LogicalType {
+	case LogicalTypeDecimal:
+		if schema.Precision <= 0 || schema.Scale < 0 || schema.Scale > schema.Precision {
+			return false
+		}
+		return schema.Type != AvroTypeFixed || schema.Precision <= MaxDecimalPrecision(size)
+	case LogicalTypeUUID:
+		return schema.Type != AvroTypeFixed || size == 16
+	case LogicalTypeDuration:
+		return size == 12
+	}
+	return true
+}
+
+func containsType(types []AvroType, avroType AvroType) bool {
+	for _, t := range types {
+		if t == avroType {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxDecimalPrecision is the number of decimal digits a two's complement
+// fixed of size bytes can always hold.
+func MaxDecimalPrecision(size int) int {
+	if size <= 0 {
+		return 0
+	}
+	return int(math.Floor(float64(8*size-1) * math.Log10(2)))
+}
@@ -0,0 +1,96 @@
+package avroschema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avroschema"
+)
+
+func TestLogicalType(t *testing.T) {
+	Convey("TestLogicalType", t, func() {
+		parse := func(data string) avroschema.Schema {
+			schema, err := avroschema.ParseSchema([]byte(data))
+			So(err, ShouldBeNil)
+			return schema
+		}
+		Convey("primitive", func() {
+			for data, expected := range map[string]avroschema.LogicalType{
+				`{"type": "int", "logicalType": "date"}`:                    avroschema.LogicalTypeDate,
+				`{"type": "int", "logicalType": "time-millis"}`:             avroschema.LogicalTypeTimeMillis,
+				`{"type": "long", "logicalType": "time-micros"}`:            avroschema.LogicalTypeTimeMicros,
+				`{"type": "long", "logicalType": "timestamp-millis"}`:       avroschema.LogicalTypeTimestampMillis,
+				`{"type": "long", "logicalType": "timestamp-micros"}`:       avroschema.LogicalTypeTimestampMicros,
+				`{"type": "long", "logicalType": "timestamp-nanos"}`:        avroschema.LogicalTypeTimestampNanos,
+				`{"type": "long", "logicalType": "local-timestamp-millis"}`: avroschema.LogicalTypeLocalTimestampMillis,
+				`{"type": "long", "logicalType": "local-timestamp-micros"}`: avroschema.LogicalTypeLocalTimestampMicros,
+				`{"type": "long", "logicalType": "local-timestamp-nanos"}`:  avroschema.LogicalTypeLocalTimestampNanos,
+				`{"type": "string", "logicalType": "uuid"}`:                 avroschema.LogicalTypeUUID,
+			} {
+				schema := parse(data).(avroschema.SchemaBase)
+				So(schema.LogicalType, ShouldEqual, expected)
+				marshalled, err := json.Marshal(schema)
+				So(err, ShouldBeNil)
+				So(parse(string(marshalled)), ShouldResemble, schema)
+			}
+		})
+		Convey("decimal", func() {
+			schema := parse(`{"type": "bytes", "logicalType": "decimal", "precision": 9, "scale": 2}`).(avroschema.SchemaBase)
+			So(schema.LogicalType, ShouldEqual, avroschema.LogicalTypeDecimal)
+			So(schema.Precision, ShouldEqual, 9)
+			So(schema.Scale, ShouldEqual, 2)
+			marshalled, err := json.Marshal(schema)
+			So(err, ShouldBeNil)
+			So(string(marshalled), ShouldEqual, `{"type":"bytes","logicalType":"decimal","precision":9,"scale":2}`)
+
+			fixed := parse(`{"type": "fixed", "name": "Money", "size": 8, "logicalType": "decimal", "precision": 18}`).(*avroschema.Fixed)
+			So(fixed.LogicalType, ShouldEqual, avroschema.LogicalTypeDecimal)
+			So(fixed.Precision, ShouldEqual, 18)
+			So(fixed.Scale, ShouldEqual, 0)
+			So(avroschema.MaxDecimalPrecision(8), ShouldEqual, 18)
+			So(avroschema.MaxDecimalPrecision(16), ShouldEqual, 38)
+		})
+		Convey("fixed", func() {
+			duration := parse(`{"type": "fixed", "name": "D", "size": 12, "logicalType": "duration"}`).(*avroschema.Fixed)
+			So(duration.LogicalType, ShouldEqual, avroschema.LogicalTypeDuration)
+			uuid := parse(`{"type": "fixed", "name": "U", "size": 16, "logicalType": "uuid"}`).(*avroschema.Fixed)
+			So(uuid.LogicalType, ShouldEqual, avroschema.LogicalTypeUUID)
+		})
+		Convey("invalid degrades to underlying type", func() {
+			for _, data := range []string{
+				`{"type": "long", "logicalType": "date"}`,
+				`{"type": "string", "logicalType": "timestamp-millis"}`,
+				`{"type": "int", "logicalType": "unknown"}`,
+				`{"type": "bytes", "logicalType": "decimal"}`,
+				`{"type": "bytes", "logicalType": "decimal", "precision": 2, "scale": 3}`,
+				`{"type": "bytes", "logicalType": "decimal", "precision": 2, "scale": -1}`,
+				`{"type": "bytes", "logicalType": "decimal", "precision": "4"}`,
+				`{"type": "bytes", "logicalType": "decimal", "precision": 4, "scale": 2.5}`,
+				`{"type": "bytes", "logicalType": 7, "precision": 4}`,
+				`{"type": "bytes", "precision": "4", "scale": {}}`,
+			} {
+				schema := parse(data).(avroschema.SchemaBase)
+				So(schema.LogicalType, ShouldEqual, avroschema.LogicalType(""))
+				So(schema.Precision, ShouldEqual, 0)
+				So(schema.Scale, ShouldEqual, 0)
+			}
+			for _, data := range []string{
+				`{"type": "fixed", "name": "F", "size": 8, "logicalType": "decimal", "precision": 19}`,
+				`{"type": "fixed", "name": "F", "size": 16, "logicalType": "duration"}`,
+				`{"type": "fixed", "name": "F", "size": 12, "logicalType": "uuid"}`,
+				`{"type": "fixed", "name": "F", "size": 8, "logicalType": "decimal", "precision": "4", "scale": 2.5}`,
+			} {
+				fixed := parse(data).(*avroschema.Fixed)
+				So(fixed.LogicalType, ShouldEqual, avroschema.LogicalType(""))
+				So(fixed.Precision, ShouldEqual, 0)
+			}
+			record := parse(`{"type": "record", "name": "R", "logicalType": "date", "fields": []}`).(*avroschema.Record)
+			So(record.LogicalType, ShouldEqual, avroschema.LogicalType(""))
+		})
+		Convey("canonical form", func() {
+			So(string(avroschema.CanonicalForm(parse(`{"type": "int", "logicalType": "date"}`))), ShouldEqual, `"int"`)
+		})
+	})
+}
@@ -1,7 +1,52 @@
 package avroschema
 
+import (
+	"fmt"
+	"strings"
+)
+
 type NamedType struct {
 	Name      string   `json:"name"`
-	Namespace string   `json:"namespace,omitempty"`
+	Namespace string   `json:"namespace"`
 	Aliases   []string `json:"aliases,omitempty"`
 }
+
+// FullName is the namespace qualified name of the type. A Name containing a
+// dot is already a full name.
+func (namedType NamedType) FullName() string {
+	if namedType.Namespace == "" || strings.Contains(namedType.Name, ".") {
+		return namedType.Name
+	}
+	return namedType.Namespace + "." + namedType.Name
+}
+
+func (namedType NamedType) validate(errs *ValidationErrors) {
+	if !isValidName(namedType.Name) {
+		errs.add(".name", "invalid name %q", namedType.Name)
+	} else if primitiveTypes[AvroType(namedType.Name)] {
+		errs.add(".name", "name %q is a primitive type", namedType.Name)
+	}
+	if namedType.Namespace != "" && !isValidFullName(namedType.Namespace) {
+		errs.add(".namespace", "invalid namespace %q", namedType.Namespace)
+	}
+	for i, alias := range namedType.Aliases {
+		if !isValidFullName(alias) {
+			errs.add(fmt.Sprintf(".aliases[%d]", i), "invalid alias %q", alias)
+		}
+	}
+}
+
+// resolve sets Namespace to the namespace the type is defined in, which is
+// taken from a dotted Name, then namespace, then the enclosing namespace.
+func (namedType *NamedType) resolve(namespace *string, enclosing string) {
+	i := strings.LastIndexByte(namedType.Name, '.')
+	switch {
+	case i >= 0:
+		namedType.Namespace = namedType.Name[:i]
+		namedType.Name = namedType.Name[i+1:]
+	case namespace != nil:
+		namedType.Namespace = *namespace
+	default:
+		namedType.Namespace = enclosing
+	}
+}
@@ -0,0 +1,23 @@
+package avroschema
+
+type parseOptions struct {
+	validate bool
+}
+
+// ParseOption configures ParseSchema and ReadSchema.
+type ParseOption func(options *parseOptions)
+
+// WithValidation validates the parsed schema, returning ValidationErrors
+// along with the schema if it violates the specification.
+func WithValidation() ParseOption {
+	return func(options *parseOptions) {
+		options.validate = true
+	}
+}
+
+func newParseOptions(options []ParseOption) (parseOptions parseOptions) {
+	for _, option := range options {
+		option(&parseOptions)
+	}
+	return
+}
@@ -3,51 +3,99 @@ package avroschema
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"strings"
 )
 
 var ErrInvalidSchema = errors.New("invalid schema")
+var ErrUndefinedName = errors.New("undefined name")
+var ErrDuplicateName = errors.New("duplicate name")
 
-func ReadSchema(reader io.Reader) (schema Schema, err error) {
+func ReadSchema(reader io.Reader, options ...ParseOption) (schema Schema, err error) {
 	var data json.RawMessage
 	err = json.NewDecoder(reader).Decode(&data)
 	if err != nil {
 		return
 	}
-	schema, err = ParseSchema(data)
+	schema, err = ParseSchema(data, options...)
 	if err != nil {
 		return
 	}
 	return
 }
 
-func ParseSchema(data []byte) (schema Schema, err error) {
+// ParseSchema parses a schema, resolving uses of named types to a *Reference
+// to their definition.
+func ParseSchema(data []byte, options ...ParseOption) (schema Schema, err error) {
+	schema, err = newSchemaParser().parse(data)
+	if err != nil {
+		return
+	}
+	if newParseOptions(options).validate {
+		err = schema.Validate()
+	}
+	return
+}
+
+var primitiveTypes = map[AvroType]bool{
+	AvroTypeNull:    true,
+	AvroTypeBoolean: true,
+	AvroTypeInt:     true,
+	AvroTypeLong:    true,
+	AvroTypeFloat:   true,
+	AvroTypeDouble:  true,
+	AvroTypeBytes:   true,
+	AvroTypeString:  true,
+}
+
+// schemaParser holds the named types defined so far, by full name, and the
+// namespace of the enclosing named type.
+type schemaParser struct {
+	names     map[string]Schema
+	namespace string
+}
+
+func newSchemaParser() *schemaParser {
+	return &schemaParser{
+		names: make(map[string]Schema),
+	}
+}
+
+func (parser *schemaParser) parse(data []byte) (schema Schema, err error) {
+	if len(data) == 0 {
+		err = ErrInvalidSchema
+		return
+	}
 	switch data[0] {
 	case '"':
-		return parseAvroType(data)
+		return parser.parseAvroType(data)
 	case '[':
-		return parseUnion(data)
+		return parser.parseUnion(data)
 	case '{':
-		return parseSchemaObject(data)
+		return parser.parseSchemaObject(data)
 	default:
 		err = ErrInvalidSchema
 		return
 	}
 }
 
-func parseAvroType(data []byte) (schema Schema, err error) {
+func (parser *schemaParser) parseAvroType(data []byte) (schema Schema, err error) {
 	var avroType AvroType
 	err = json.Unmarshal(data, &avroType)
 	if err != nil {
 		return
 	}
-	schema = avroType
-	return
+	if primitiveTypes[avroType] {
+		schema = avroType
+		return
+	}
+	return parser.lookup(string(avroType))
 }
 
-func parseUnion(data []byte) (schema Schema, err error) {
+func (parser *schemaParser) parseUnion(data []byte) (schema Schema, err error) {
 	var union Union
-	err = json.Unmarshal(data, &union)
+	err = parser.decodeUnion(data, &union)
 	if err != nil {
 		return
 	}
@@ -55,45 +103,39 @@ func parseUnion(data []byte) (schema Schema, err error) {
 	return
 }
 
-func parseSchemaObject(data []byte) (schema Schema, err error) {
+func (parser *schemaParser) parseSchemaObject(data []byte) (schema Schema, err error) {
 	var schemaBase SchemaBase
-	err = json.Unmarshal(data, &schemaBase)
+	err = unmarshalSchema(data, &schemaBase, &schemaBase)
 	if err != nil {
 		return
 	}
-	parse, ok := schemaParsers[schemaBase.Type]
-	if !ok {
+	switch schemaBase.Type {
+	case AvroTypeRecord:
+		return parser.parseRecord(data)
+	case AvroTypeEnum:
+		return parser.parseEnum(data)
+	case AvroTypeArray:
+		return parser.parseArray(data)
+	case AvroTypeMap:
+		return parser.parseMap(data)
+	case AvroTypeFixed:
+		return parser.parseFixed(data)
+	}
+	if schemaBase.Type == "" {
 		err = ErrInvalidSchema
 		return
 	}
-	if parse == nil {
+	if primitiveTypes[schemaBase.Type] {
+		schemaBase.resolveLogicalType(0)
 		schema = schemaBase
 		return
 	}
-	return parse(data)
-}
-
-type parseSchemaFunc func(data []byte) (Schema, error)
-
-var schemaParsers = map[AvroType]parseSchemaFunc{
-	AvroTypeNull:    nil,
-	AvroTypeBoolean: nil,
-	AvroTypeInt:     nil,
-	AvroTypeLong:    nil,
-	AvroTypeFloat:   nil,
-	AvroTypeDouble:  nil,
-	AvroTypeBytes:   nil,
-	AvroTypeString:  nil,
-	AvroTypeRecord:  parseRecord,
-	AvroTypeEnum:    parseEnum,
-	AvroTypeArray:   parseArray,
-	AvroTypeMap:     parseMap,
-	AvroTypeFixed:   parseFixed,
+	return parser.lookup(string(schemaBase.Type))
 }
 
-func parseRecord(data []byte) (schema Schema, err error) {
+func (parser *schemaParser) parseRecord(data []byte) (schema Schema, err error) {
 	record := new(Record)
-	err = json.Unmarshal(data, record)
+	err = parser.decodeRecord(data, record)
 	if err != nil {
 		return
 	}
@@ -101,9 +143,14 @@ func parseRecord(data []byte) (schema Schema, err error) {
 	return
 }
 
-func parseEnum(data []byte) (schema Schema, err error) {
+func (parser *schemaParser) parseEnum(data []byte) (schema Schema, err error) {
 	enum := new(Enum)
-	err = json.Unmarshal(data, enum)
+	err = unmarshalSchema(data, enum, &enum.SchemaBase)
+	if err != nil {
+		return
+	}
+	enum.resolveLogicalType(0)
+	err = parser.define(data, &enum.NamedType, enum)
 	if err != nil {
 		return
 	}
@@ -111,9 +158,9 @@ func parseEnum(data []byte) (schema Schema, err error) {
 	return
 }
 
-func parseArray(data []byte) (schema Schema, err error) {
+func (parser *schemaParser) parseArray(data []byte) (schema Schema, err error) {
 	array := new(Array)
-	err = json.Unmarshal(data, array)
+	err = parser.decodeArray(data, array)
 	if err != nil {
 		return
 	}
@@ -121,9 +168,9 @@ func parseArray(data []byte) (schema Schema, err error) {
 	return
 }
 
-func parseMap(data []byte) (schema Schema, err error) {
+func (parser *schemaParser) parseMap(data []byte) (schema Schema, err error) {
 	avroMap := new(Map)
-	err = json.Unmarshal(data, avroMap)
+	err = parser.decodeMap(data, avroMap)
 	if err != nil {
 		return
 	}
@@ -131,12 +178,176 @@ func parseMap(data []byte) (schema Schema, err error) {
 	return
 }
 
-func parseFixed(data []byte) (schema Schema, err error) {
+func (parser *schemaParser) parseFixed(data []byte) (schema Schema, err error) {
 	fixed := new(Fixed)
-	err = json.Unmarshal(data, fixed)
+	err = unmarshalSchema(data, fixed, &fixed.SchemaBase)
+	if err != nil {
+		return
+	}
+	fixed.resolveLogicalType(fixed.Size)
+	err = parser.define(data, &fixed.NamedType, fixed)
 	if err != nil {
 		return
 	}
 	schema = fixed
 	return
 }
+
+func (parser *schemaParser) decodeRecord(data []byte, record *Record) (err error) {
+	var base struct {
+		SchemaBase
+		NamedType
+		Fields []json.RawMessage `json:"fields"`
+	}
+	err = unmarshalSchema(data, &base, &base.SchemaBase)
+	if err != nil {
+		return
+	}
+	if base.SchemaBase.Type != "record" {
+		return fmt.Errorf("expected type 'record', got %s", base.Type)
+	}
+	// the record is defined before its fields are parsed so that they can
+	// refer to it
+	err = parser.define(data, &base.NamedType, record)
+	if err != nil {
+		return
+	}
+	record.SchemaBase = base.SchemaBase
+	record.resolveLogicalType(0)
+	record.NamedType = base.NamedType
+	enclosing := parser.namespace
+	parser.namespace = record.Namespace
+	defer func() {
+		parser.namespace = enclosing
+	}()
+	record.Fields = make([]*RecordField, len(base.Fields))
+	for i, fieldData := range base.Fields {
+		field := new(RecordField)
+		err = parser.decodeRecordField(fieldData, field)
+		if err != nil {
+			return
+		}
+		record.Fields[i] = field
+	}
+	return
+}
+
+func (parser *schemaParser) decodeRecordField(data []byte, field *RecordField) (err error) {
+	var base struct {
+		Name string          `json:"name"`
+		Type json.RawMessage `json:"type"`
+	}
+	err = json.Unmarshal(data, &base)
+	if err != nil {
+		return
+	}
+	fieldType, err := parser.parse(base.Type)
+	if err != nil {
+		return
+	}
+	field.Name = base.Name
+	field.Type = fieldType
+	return
+}
+
+func (parser *schemaParser) decodeArray(data []byte, array *Array) (err error) {
+	var base struct {
+		SchemaBase
+		Items json.RawMessage `json:"items"`
+	}
+	err = unmarshalSchema(data, &base, &base.SchemaBase)
+	if err != nil {
+		return
+	}
+	if base.SchemaBase.Type != "array" {
+		return fmt.Errorf("expected type 'array', got %s", base.Type)
+	}
+	items, err := parser.parse(base.Items)
+	if err != nil {
+		return
+	}
+	array.SchemaBase = base.SchemaBase
+	array.resolveLogicalType(0)
+	array.Items = items
+	return
+}
+
+func (parser *schemaParser) decodeMap(data []byte, avroMap *Map) (err error) {
+	var base struct {
+		SchemaBase
+		Values json.RawMessage `json:"values"`
+	}
+	err = unmarshalSchema(data, &base, &base.SchemaBase)
+	if err != nil {
+		return
+	}
+	if base.SchemaBase.Type != "map" {
+		return fmt.Errorf("expected type 'map', got %s", base.Type)
+	}
+	values, err := parser.parse(base.Values)
+	if err != nil {
+		return
+	}
+	avroMap.SchemaBase = base.SchemaBase
+	avroMap.resolveLogicalType(0)
+	avroMap.Values = values
+	return
+}
+
+func (parser *schemaParser) decodeUnion(data []byte, union *Union) (err error) {
+	var items []json.RawMessage
+	err = json.Unmarshal(data, &items)
+	if err != nil {
+		return
+	}
+	schemas := make([]Schema, len(items))
+	for i, item := range items {
+		schemas[i], err = parser.parse(item)
+		if err != nil {
+			return
+		}
+	}
+	*union = schemas
+	return
+}
+
+// define resolves the namespace of namedType from the schema data and adds
+// schema to the named types.
+func (parser *schemaParser) define(data []byte, namedType *NamedType, schema Schema) (err error) {
+	var base struct {
+		Namespace *string `json:"namespace"`
+	}
+	err = json.Unmarshal(data, &base)
+	if err != nil {
+		return
+	}
+	namedType.resolve(base.Namespace, parser.namespace)
+	fullName := namedType.FullName()
+	_, ok := parser.names[fullName]
+	if ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateName, fullName)
+	}
+	parser.names[fullName] = schema
+	return
+}
+
+// lookup resolves a use of a named type. A name without a dot is looked up
+// in the enclosing namespace, then the null namespace.
+func (parser *schemaParser) lookup(name string) (schema Schema, err error) {
+	fullNames := []string{name}
+	if !strings.Contains(name, ".") && parser.namespace != "" {
+		fullNames = []string{parser.namespace + "." + name, name}
+	}
+	for _, fullName := range fullNames {
+		definition, ok := parser.names[fullName]
+		if ok {
+			schema = &Reference{
+				Name:   fullName,
+				Schema: definition,
+			}
+			return
+		}
+	}
+	err = fmt.Errorf("%w: %s", ErrUndefinedName, name)
+	return
+}
@@ -1,6 +1,8 @@
 package avroschema_test
 
 import (
+	"encoding/json"
+	"errors"
 	"os"
 	"testing"
 
@@ -197,6 +199,99 @@ func TestParseSchema(t *testing.T) {
 					So(fixed.Name, ShouldEqual, "chunk")
 					So(fixed.Size, ShouldEqual, 16)
 				})
+				Convey("references", func() {
+					file, err := os.Open("testdata/schemas/complex_types/complex/references.json")
+					So(err, ShouldBeNil)
+					schema, err := avroschema.ReadSchema(file)
+					So(err, ShouldBeNil)
+					err = file.Close()
+					So(err, ShouldBeNil)
+					customer := schema.(*avroschema.Record)
+					So(customer.FullName(), ShouldEqual, "com.acme.Customer")
+
+					address := customer.Fields[0].Type.(*avroschema.Record)
+					So(address.FullName(), ShouldEqual, "com.acme.Address")
+					country := address.Fields[0].Type.(*avroschema.Enum)
+					So(country.Name, ShouldEqual, "Country")
+					So(country.FullName(), ShouldEqual, "org.iso.Country")
+					postcode := address.Fields[1].Type.(*avroschema.Fixed)
+					So(postcode.FullName(), ShouldEqual, "com.acme.Postcode")
+
+					shipping := customer.Fields[1].Type.(avroschema.Union)
+					reference := shipping[1].(*avroschema.Reference)
+					So(reference.Name, ShouldEqual, "com.acme.Address")
+					So(reference.Schema, ShouldEqual, address)
+					So(reference.GetType(), ShouldEqual, avroschema.AvroTypeRecord)
+
+					reference = customer.Fields[2].Type.(*avroschema.Reference)
+					So(reference.Schema, ShouldEqual, address)
+					reference = customer.Fields[3].Type.(*avroschema.Reference)
+					So(reference.Schema, ShouldEqual, country)
+
+					referrer := customer.Fields[4].Type.(avroschema.Union)
+					reference = referrer[1].(*avroschema.Reference)
+					So(reference.Name, ShouldEqual, "com.acme.Customer")
+					So(reference.Schema, ShouldEqual, customer)
+
+					Convey("round trip", func() {
+						data, err := json.Marshal(schema)
+						So(err, ShouldBeNil)
+						parsed, err := avroschema.ParseSchema(data)
+						So(err, ShouldBeNil)
+						So(parsed.(*avroschema.Record).FullName(), ShouldEqual, "com.acme.Customer")
+					})
+				})
+			})
+		})
+		Convey("named types", func() {
+			Convey("undefined", func() {
+				_, err := avroschema.ParseSchema([]byte(`{"type": "array", "items": "com.acme.Address"}`))
+				So(errors.Is(err, avroschema.ErrUndefinedName), ShouldBeTrue)
+				_, err = avroschema.ParseSchema([]byte(`{"type": "com.acme.Address"}`))
+				So(errors.Is(err, avroschema.ErrUndefinedName), ShouldBeTrue)
+			})
+			Convey("defined later", func() {
+				_, err := avroschema.ParseSchema([]byte(`["Later", {"type": "fixed", "name": "Later", "size": 1}]`))
+				So(errors.Is(err, avroschema.ErrUndefinedName), ShouldBeTrue)
+			})
+			Convey("duplicate", func() {
+				_, err := avroschema.ParseSchema([]byte(`[
+					{"type": "fixed", "name": "a.MD5", "size": 16},
+					{"type": "enum", "name": "MD5", "namespace": "a", "symbols": ["X"]}
+				]`))
+				So(errors.Is(err, avroschema.ErrDuplicateName), ShouldBeTrue)
+			})
+			Convey("null namespace", func() {
+				schema, err := avroschema.ParseSchema([]byte(`[
+					{"type": "fixed", "name": "MD5", "size": 16},
+					{"type": "record", "name": "a.Hashed", "fields": [
+						{"name": "hash", "type": "MD5"},
+						{"name": "inner", "type": {"type": "fixed", "name": "MD5", "namespace": "", "size": 1}}
+					]}
+				]`))
+				So(errors.Is(err, avroschema.ErrDuplicateName), ShouldBeTrue)
+				schema, err = avroschema.ParseSchema([]byte(`[
+					{"type": "fixed", "name": "MD5", "size": 16},
+					{"type": "record", "name": "a.Hashed", "fields": [
+						{"name": "hash", "type": "MD5"}
+					]}
+				]`))
+				So(err, ShouldBeNil)
+				union := schema.(avroschema.Union)
+				reference := union[1].(*avroschema.Record).Fields[0].Type.(*avroschema.Reference)
+				So(reference.Name, ShouldEqual, "MD5")
+				So(reference.Schema, ShouldEqual, union[0])
+
+				schema, err = avroschema.ParseSchema([]byte(`{"type": "record", "name": "a.Hashed", "fields": [
+					{"name": "hash", "type": {"type": "fixed", "name": "MD5", "namespace": "", "size": 16}}
+				]}`))
+				So(err, ShouldBeNil)
+				data, err := json.Marshal(schema)
+				So(err, ShouldBeNil)
+				parsed, err := avroschema.ParseSchema(data)
+				So(err, ShouldBeNil)
+				So(parsed.(*avroschema.Record).Fields[0].Type.(*avroschema.Fixed).FullName(), ShouldEqual, "MD5")
+				So(avroschema.CanonicalForm(parsed), ShouldResemble, avroschema.CanonicalForm(schema))
 			})
 		})
 	})
@@ -1,6 +1,6 @@
 package avroschema
 
-import "encoding/json"
+import "fmt"
 
 type Record struct {
 	SchemaBase
@@ -8,6 +8,30 @@ type Record struct {
 	Fields []*RecordField `json:"fields"`
 }
 
+func (record *Record) Validate() error {
+	var errs ValidationErrors
+	if record.Type != AvroTypeRecord {
+		errs.add(".type", "expected type 'record', got %s", record.Type)
+	}
+	record.NamedType.validate(&errs)
+	names := make(map[string]bool, len(record.Fields))
+	for i, field := range record.Fields {
+		path := fmt.Sprintf(".fields[%d]", i)
+		if !isValidName(field.Name) {
+			errs.add(path+".name", "invalid name %q", field.Name)
+		} else if names[field.Name] {
+			errs.add(path+".name", "duplicate field %q", field.Name)
+		}
+		names[field.Name] = true
+		errs.addSchema(path+".type", field.Type)
+	}
+	return errs.err()
+}
+
+func (record *Record) UnmarshalJSON(data []byte) (err error) {
+	return newSchemaParser().decodeRecord(data, record)
+}
+
 type RecordField struct {
 	Name    string      `json:"name"`
 	Type    Schema      `json:"type"`
@@ -15,19 +39,5 @@ type RecordField struct {
 }
 
 func (field *RecordField) UnmarshalJSON(data []byte) (err error) {
-	var base struct {
-		Name string          `json:"name"`
-		Type json.RawMessage `json:"type"`
-	}
-	err = json.Unmarshal(data, &base)
-	if err != nil {
-		return
-	}
-	fieldType, err := ParseSchema(base.Type)
-	if err != nil {
-		return
-	}
-	field.Name = base.Name
-	field.Type = fieldType
-	return
+	return newSchemaParser().decodeRecordField(data, field)
 }
@@ -0,0 +1,29 @@
+package avroschema
+
+import "encoding/json"
+
+// Reference is a use of a named type by name after its definition. Schema is
+// the definition, which for a recursive type may enclose the reference.
+type Reference struct {
+	Name   string
+	Schema Schema
+}
+
+func (reference *Reference) GetType() AvroType {
+	return reference.Schema.GetType()
+}
+
+// Validate does not validate the definition, which is validated where it is
+// defined.
+func (reference *Reference) Validate() error {
+	if reference.Schema != nil {
+		return nil
+	}
+	var errs ValidationErrors
+	errs.add("", "undefined name %q", reference.Name)
+	return errs
+}
+
+func (reference *Reference) MarshalJSON() ([]byte, error) {
+	return json.Marshal(reference.Name)
+}
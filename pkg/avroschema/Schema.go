@@ -2,13 +2,28 @@ package avroschema
 
 type Schema interface {
 	GetType() AvroType
-	// TODO Validate() error
+	// Validate checks the schema against the specification, returning
+	// ValidationErrors with every violation found.
+	Validate() error
 }
 
 type SchemaBase struct {
-	Type AvroType `json:"type"`
+	Type        AvroType    `json:"type"`
+	LogicalType LogicalType `json:"logicalType,omitempty"`
+	// Precision and Scale are the attributes of LogicalTypeDecimal.
+	Precision int `json:"precision,omitempty"`
+	Scale     int `json:"scale,omitempty"`
 }
 
 func (schema SchemaBase) GetType() AvroType {
 	return schema.Type
 }
+
+func (schema SchemaBase) Validate() error {
+	if primitiveTypes[schema.Type] {
+		return nil
+	}
+	var errs ValidationErrors
+	errs.add(".type", "unknown type %q", schema.Type)
+	return errs
+}
@@ -1,6 +1,6 @@
 package avroschema
 
-import "encoding/json"
+import "fmt"
 
 type Union []Schema
 
@@ -8,19 +8,41 @@ func (union Union) GetType() AvroType {
 	return AvroTypeUnion
 }
 
-func (union *Union) UnmarshalJSON(data []byte) (err error) {
-	var items []json.RawMessage
-	err = json.Unmarshal(data, &items)
-	if err != nil {
-		return
-	}
-	schemas := make([]Schema, len(items))
-	for i, item := range items {
-		schemas[i], err = ParseSchema(item)
-		if err != nil {
-			return
+// Validate checks that the union has no nested unions and no two schemas of
+// the same type, other than named types with different names.
+func (union Union) Validate() error {
+	var errs ValidationErrors
+	types := make(map[string]bool, len(union))
+	for i, schema := range union {
+		path := fmt.Sprintf("[%d]", i)
+		errs.addSchema(path, schema)
+		if schema == nil {
+			continue
+		}
+		if schema.GetType() == AvroTypeUnion {
+			errs.add(path, "union contains a union")
+			continue
 		}
+		key := unionKey(schema)
+		if types[key] {
+			errs.add(path, "union contains %s more than once", key)
+		}
+		types[key] = true
+	}
+	return errs.err()
+}
+
+// unionKey is the name of a named type, otherwise its type.
+func unionKey(schema Schema) string {
+	switch schema := schema.(type) {
+	case *Reference:
+		return schema.Name
+	case interface{ FullName() string }:
+		return schema.FullName()
 	}
-	*union = schemas
-	return
+	return string(schema.GetType())
+}
+
+func (union *Union) UnmarshalJSON(data []byte) (err error) {
+	return newSchemaParser().decodeUnion(data, union)
 }
@@ -0,0 +1,95 @@
+package avroschema_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/Ryan-A-B/avro-go/pkg/avroschema"
+)
+
+func validationPaths(err error) (paths []string) {
+	var errs avroschema.ValidationErrors
+	So(errors.As(err, &errs), ShouldBeTrue)
+	for _, err := range errs {
+		paths = append(paths, err.Path)
+	}
+	return
+}
+
+func TestValidate(t *testing.T) {
+	Convey("TestValidate", t, func() {
+		Convey("valid", func() {
+			for _, name := range []string{
+				"complex_types/complex/record.json",
+				"complex_types/complex/union.json",
+				"complex_types/complex/references.json",
+			} {
+				file, err := os.Open("testdata/schemas/" + name)
+				So(err, ShouldBeNil)
+				_, err = avroschema.ReadSchema(file, avroschema.WithValidation())
+				So(err, ShouldBeNil)
+				err = file.Close()
+				So(err, ShouldBeNil)
+			}
+		})
+		Convey("without option", func() {
+			schema, err := avroschema.ParseSchema([]byte(`{"type": "fixed", "name": "Bad", "size": -1}`))
+			So(err, ShouldBeNil)
+			So(validationPaths(schema.Validate()), ShouldResemble, []string{"$.size"})
+		})
+		Convey("all violations", func() {
+			_, err := avroschema.ParseSchema([]byte(`{
+				"type": "record",
+				"name": "1Record",
+				"namespace": "com.acme",
+				"fields": [
+					{"name": "a", "type": "int"},
+					{"name": "a", "type": "long"},
+					{"name": "b-c", "type": "string"},
+					{"name": "suit", "type": {
+						"type": "enum",
+						"name": "Suit",
+						"symbols": ["HEARTS", "HEARTS", "no spaces"],
+						"default": "CLUBS"
+					}},
+					{"name": "hash", "type": {"type": "fixed", "name": "int", "namespace": "com..acme", "size": -16}},
+					{"name": "arrays", "type": [
+						{"type": "array", "items": "int"},
+						{"type": "array", "items": "long"}
+					]},
+					{"name": "nested", "type": ["null", ["int", "long"], "null"]},
+					{"name": "named", "type": ["Suit", {"type": "enum", "name": "Other", "symbols": ["X"]}]}
+				]
+			}`), avroschema.WithValidation())
+			So(validationPaths(err), ShouldResemble, []string{
+				"$.name",
+				"$.fields[1].name",
+				"$.fields[2].name",
+				"$.fields[3].type.symbols[1]",
+				"$.fields[3].type.symbols[2]",
+				"$.fields[3].type.default",
+				"$.fields[4].type.name",
+				"$.fields[4].type.namespace",
+				"$.fields[4].type.size",
+				"$.fields[5].type[1]",
+				"$.fields[6].type[1]",
+				"$.fields[6].type[2]",
+			})
+			So(err.Error(), ShouldContainSubstring, `$.fields[1].name: duplicate field "a"`)
+		})
+		Convey("duplicate named types in union", func() {
+			_, err := avroschema.ParseSchema([]byte(`[
+				{"type": "fixed", "name": "MD5", "size": 16},
+				"MD5"
+			]`), avroschema.WithValidation())
+			So(validationPaths(err), ShouldResemble, []string{"$[1]"})
+		})
+		Convey("unknown type", func() {
+			So(validationPaths(avroschema.AvroType("record").Validate()), ShouldResemble, []string{"$"})
+			So(avroschema.AvroTypeString.Validate(), ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,83 @@
+package avroschema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ValidationError is a violation of the specification at Path, a JSON path
+// into the schema such as $.fields[1].type.
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (err *ValidationError) Error() string {
+	return err.Path + ": " + err.Message
+}
+
+// ValidationErrors is returned by Validate with every violation found.
+type ValidationErrors []*ValidationError
+
+func (errs ValidationErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (errs *ValidationErrors) add(path string, format string, args ...interface{}) {
+	*errs = append(*errs, &ValidationError{
+		Path:    "$" + path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// addSchema validates schema, adding its errors under path.
+func (errs *ValidationErrors) addSchema(path string, schema Schema) {
+	if schema == nil {
+		errs.add(path, "missing schema")
+		return
+	}
+	err := schema.Validate()
+	if err == nil {
+		return
+	}
+	schemaErrs, ok := err.(ValidationErrors)
+	if !ok {
+		errs.add(path, "%s", err)
+		return
+	}
+	for _, schemaErr := range schemaErrs {
+		*errs = append(*errs, &ValidationError{
+			Path:    "$" + path + strings.TrimPrefix(schemaErr.Path, "$"),
+			Message: schemaErr.Message,
+		})
+	}
+}
+
+func (errs ValidationErrors) err() error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+var namePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func isValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// isValidFullName reports whether name is a sequence of names separated by
+// dots.
+func isValidFullName(name string) bool {
+	for _, part := range strings.Split(name, ".") {
+		if !isValidName(part) {
+			return false
+		}
+	}
+	return true
+}